@@ -0,0 +1,288 @@
+/*
+Package chans provides streaming counterparts of fp combinators working on
+channels instead of slices.
+
+Every combinator takes a context.Context and stops as soon as it's done.
+Output channels are closed once the input is exhausted or the context is
+cancelled, so ranging over them always terminates. Inputs aren't drained on
+cancellation; use Drain() if producers must not block.
+*/
+package chans
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/loorke/fp"
+)
+
+// Sends v unless ctx is done
+func send[tA any](ctx context.Context, out chan<- tA, v tA) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Receives from in unless ctx is done; ok is false if in is closed too
+func recv[tA any](ctx context.Context, in <-chan tA) (v tA, ok bool) {
+	select {
+	case v, ok = <-in:
+		return v, ok
+	case <-ctx.Done():
+		return v, false
+	}
+}
+
+// Calls f for each value received from in until it returns false or in is
+// closed; returns ctx.Err() only if it stopped because ctx is done
+func each[tA any](ctx context.Context, in <-chan tA, f func(tA) bool) error {
+	for {
+		select {
+		case v, ok := <-in:
+			if !ok || !f(v) {
+				return nil
+			}
+		case <-ctx.Done():
+			// Input may have been exhausted by then, which isn't a
+			// cancellation; a value received here is dropped
+			select {
+			case _, ok := <-in:
+				if !ok {
+					return nil
+				}
+			default:
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+// Returns channel emitting a
+func FromSlice[tA any](ctx context.Context, a ...tA) <-chan tA {
+	out := make(chan tA)
+	go func() {
+		defer close(out)
+		for _, v := range a {
+			if !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func MapChan[
+	tA, tB any,
+	tF ~func(tA) tB,
+](ctx context.Context, f tF, in <-chan tA) <-chan tB {
+	out := make(chan tB)
+	go func() {
+		defer close(out)
+		each(ctx, in, func(v tA) bool {
+			return send(ctx, out, f(v))
+		})
+	}()
+	return out
+}
+
+func FilterChan[
+	tA any,
+	tF ~func(tA) bool,
+](ctx context.Context, p tF, in <-chan tA) <-chan tA {
+	out := make(chan tA)
+	go func() {
+		defer close(out)
+		each(ctx, in, func(v tA) bool {
+			return !p(v) || send(ctx, out, v)
+		})
+	}()
+	return out
+}
+
+// Folds values of in until it's closed; returns ctx.Err() with the partial
+// result if ctx is done first
+func ReduceChan[
+	tA, tB any,
+	tF ~func(tB, tA) tB,
+](ctx context.Context, f tF, z tB, in <-chan tA) (tB, error) {
+	acc := z
+	err := each(ctx, in, func(v tA) bool {
+		acc = f(acc, v)
+		return true
+	})
+	return acc, err
+}
+
+// Pairs values of a and b; stops when either is closed
+func ZipChan[tA, tB any](
+	ctx context.Context,
+	a <-chan tA,
+	b <-chan tB,
+) <-chan fp.Tuple[tA, tB] {
+	out := make(chan fp.Tuple[tA, tB])
+	go func() {
+		defer close(out)
+		for {
+			va, ok := recv(ctx, a)
+			if !ok {
+				return
+			}
+			vb, ok := recv(ctx, b)
+			if !ok {
+				return
+			}
+			if !send(ctx, out, fp.Tuple[tA, tB]{A: va, B: vb}) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Fans in values of ins into a single channel, closed when all of ins are
+func Merge[tA any](ctx context.Context, ins ...<-chan tA) <-chan tA {
+	out := make(chan tA)
+	var wg sync.WaitGroup
+	for _, in := range ins {
+		wg.Add(1)
+		go func(in <-chan tA) {
+			defer wg.Done()
+			each(ctx, in, func(v tA) bool {
+				return send(ctx, out, v)
+			})
+		}(in)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Distributes values of in among n channels; each value is delivered to a
+// single one of them, whichever is ready first
+func FanOut[tA any](ctx context.Context, n int, in <-chan tA) []<-chan tA {
+	fp.Must(fp.Gt(0), "number of outputs must be positive", n)
+
+	outs := make([]<-chan tA, n)
+	for i := range outs {
+		out := make(chan tA)
+		outs[i] = out
+		go func() {
+			defer close(out)
+			each(ctx, in, func(v tA) bool {
+				return send(ctx, out, v)
+			})
+		}()
+	}
+	return outs
+}
+
+// Duplicates every value of in into n channels. A value is delivered to all
+// of them before the next one is received, so the slowest reader sets the
+// pace.
+func Tee[tA any](ctx context.Context, n int, in <-chan tA) []<-chan tA {
+	fp.Must(fp.Gt(0), "number of outputs must be positive", n)
+
+	outs := make([]chan tA, n)
+	for i := range outs {
+		outs[i] = make(chan tA)
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		each(ctx, in, func(v tA) bool {
+			return fp.All(func(out chan tA) bool {
+				return send(ctx, out, v)
+			}, outs...)
+		})
+	}()
+
+	return fp.Map(func(out chan tA) <-chan tA {
+		return out
+	}, outs...)
+}
+
+// Groups values of in into batches of up to size elements. A batch is
+// emitted once it's full or timeout has elapsed since its first element;
+// the incomplete last batch is emitted when in is closed.
+func Batch[tA any](
+	ctx context.Context,
+	size int,
+	timeout time.Duration,
+	in <-chan tA,
+) <-chan []tA {
+	fp.Must(fp.Gt(0), "batch size must be positive", size)
+
+	out := make(chan []tA)
+	go func() {
+		defer close(out)
+
+		var batch []tA
+		timer := time.NewTimer(timeout)
+		timer.Stop()
+		defer timer.Stop()
+
+		flush := func() bool {
+			// Drop the pending tick, if any, so it doesn't cut the next batch
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			if len(batch) == 0 {
+				return true
+			}
+			b := batch
+			batch = nil
+			return send(ctx, out, b)
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				if !flush() {
+					return
+				}
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				if len(batch) == 0 {
+					timer.Reset(timeout)
+				}
+				batch = append(batch, v)
+				if len(batch) == size && !flush() {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// Discards values of in until it's closed or ctx is done
+func Drain[tA any](ctx context.Context, in <-chan tA) error {
+	return each(ctx, in, func(tA) bool {
+		return true
+	})
+}
+
+// Receives values of in until it's closed; returns ctx.Err() with the values
+// received so far if ctx is done first
+func Collect[tA any](ctx context.Context, in <-chan tA) ([]tA, error) {
+	return ReduceChan(ctx, func(acc []tA, v tA) []tA {
+		return append(acc, v)
+	}, []tA{}, in)
+}
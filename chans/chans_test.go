@@ -0,0 +1,155 @@
+package chans
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/loorke/fp"
+	"github.com/loorke/fp/internal/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapFilterReduce(t *testing.T) {
+	leaktest.Check(t, func() {
+		ctx := context.Background()
+		in := FromSlice(ctx, 1, 2, 3, 4, 5)
+		even := FilterChan(ctx, fp.IsEven[int], in)
+		strs := MapChan(ctx, strconv.Itoa, even)
+
+		res, err := ReduceChan(ctx, func(acc, s string) string {
+			return acc + s
+		}, "", strs)
+		require.NoError(t, err)
+		require.Equal(t, "24", res)
+	})
+}
+
+func TestZipChan(t *testing.T) {
+	leaktest.Check(t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		res, err := Collect(ctx, ZipChan(ctx,
+			FromSlice(ctx, 1, 2, 3),
+			FromSlice(ctx, "a", "b")))
+		require.NoError(t, err)
+		require.Equal(t, []fp.Tuple[int, string]{{A: 1, B: "a"}, {A: 2, B: "b"}}, res)
+	})
+}
+
+func TestMergeFanOut(t *testing.T) {
+	leaktest.Check(t, func() {
+		ctx := context.Background()
+		outs := FanOut(ctx, 3, FromSlice(ctx, 1, 2, 3, 4, 5, 6, 7, 8))
+		require.Len(t, outs, 3)
+
+		incremented := fp.Map(func(out <-chan int) <-chan int {
+			return MapChan(ctx, fp.Add(1), out)
+		}, outs...)
+
+		res, err := Collect(ctx, Merge(ctx, incremented...))
+		require.NoError(t, err)
+		sort.Ints(res)
+		require.Equal(t, []int{2, 3, 4, 5, 6, 7, 8, 9}, res)
+	})
+}
+
+func TestTee(t *testing.T) {
+	leaktest.Check(t, func() {
+		ctx := context.Background()
+		outs := Tee(ctx, 2, FromSlice(ctx, 1, 2, 3))
+
+		sums := make(chan int)
+		for _, out := range outs {
+			go func(out <-chan int) {
+				sums <- fp.Sum(fp.NoError(Collect(ctx, out))...)
+			}(out)
+		}
+		require.Equal(t, 6, <-sums)
+		require.Equal(t, 6, <-sums)
+	})
+}
+
+func TestBatch(t *testing.T) {
+	leaktest.Check(t, func() {
+		ctx := context.Background()
+
+		{
+			res, err := Collect(ctx,
+				Batch(ctx, 2, time.Hour, FromSlice(ctx, 1, 2, 3, 4, 5)))
+			require.NoError(t, err)
+			require.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, res)
+		}
+
+		{
+			in := make(chan int)
+			out := Batch(ctx, 10, 20*time.Millisecond, in)
+			in <- 1
+			in <- 2
+			require.Equal(t, []int{1, 2}, <-out)
+
+			in <- 3
+			close(in)
+			require.Equal(t, []int{3}, <-out)
+			_, ok := <-out
+			require.False(t, ok)
+		}
+	})
+}
+
+func TestCancel(t *testing.T) {
+	leaktest.Check(t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// Nobody reads the outputs, every stage is blocked on send
+		in := make(chan int)
+		_ = MapChan(ctx, fp.Add(1), in)
+		_ = Merge(ctx, FromSlice(ctx, 1, 2), FromSlice(ctx, 3))
+		_ = Tee(ctx, 2, FromSlice(ctx, 1, 2))
+		_ = FanOut(ctx, 2, FromSlice(ctx, 1, 2))
+		_ = Batch(ctx, 1, time.Hour, FromSlice(ctx, 1, 2))
+
+		cancel()
+
+		_, err := Collect(ctx, in)
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, Drain(ctx, in), context.Canceled)
+	})
+}
+
+func TestCancelAfterExhausted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	in := make(chan int, 3)
+	in <- 1
+	in <- 2
+	in <- 3
+	close(in)
+
+	// Cancelled once the last value is folded, but before the loop ends
+	res, err := ReduceChan(ctx, func(acc, v int) int {
+		if v == 3 {
+			cancel()
+		}
+		return acc + v
+	}, 0, in)
+	require.NoError(t, err)
+	require.Equal(t, 6, res)
+
+	done := make(chan int)
+	close(done)
+	require.NoError(t, Drain(ctx, done))
+	_, err = Collect(ctx, done)
+	require.NoError(t, err)
+}
+
+func TestDrain(t *testing.T) {
+	leaktest.Check(t, func() {
+		ctx := context.Background()
+		require.NoError(t, Drain(ctx, FromSlice(ctx, 1, 2, 3)))
+	})
+}
@@ -0,0 +1,37 @@
+package fp
+
+import (
+	"context"
+	"time"
+)
+
+//////////
+/// Time
+
+// Source of time, injectable for tests
+type Clock interface {
+	Now() time.Time
+	// Sends current time once d has elapsed, see time.After()
+	After(d time.Duration) <-chan time.Time
+}
+
+// Clock backed by package time
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Blocks for d unless ctx is done first, returns ctx.Err() in such a case
+func sleep(ctx context.Context, clock Clock, d time.Duration) error {
+	select {
+	case <-clock.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
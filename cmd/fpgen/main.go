@@ -0,0 +1,313 @@
+// Command fpgen generates fixed-arity function families of package fp:
+// Tuple, Zip, Apply, Lazy, Uncurry, Partial, Compose, Pipe, NoError and
+// Memoize.
+//
+// Usage:
+//
+//	go run ./cmd/fpgen -n 8 -o fp_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// Bounded by the number of latin letters available for type parameters of
+// the widest family (Compose).
+const MaxArity = 12
+
+// Header of generated files; the arity is recorded so that the output can be
+// reproduced.
+const headerFmt = "// Code generated by fpgen -n %d. DO NOT EDIT.\n"
+
+func main() {
+	n := flag.Int("n", 8, fmt.Sprintf("maximum arity, up to %d", MaxArity))
+	out := flag.String("o", "fp_gen.go", "output file")
+	pkg := flag.String("pkg", "fp", "package name")
+	flag.Parse()
+
+	src, err := Generate(*pkg, *n)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Parses the arity recorded in the header of a generated file
+func ParseHeader(src []byte) (n int, err error) {
+	line, _, _ := bytes.Cut(src, []byte("\n"))
+	_, err = fmt.Sscanf(string(line)+"\n", headerFmt, &n)
+	return n, err
+}
+
+// Returns formatted source of families up to arity n
+func Generate(pkg string, n int) ([]byte, error) {
+	if n < 2 || n > MaxArity {
+		return nil, fmt.Errorf("arity must be within [2, %d], got %d",
+			MaxArity, n)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, headerFmt, n)
+	fmt.Fprintf(&buf, "\npackage %s\n", pkg)
+
+	for _, f := range families {
+		for i := f.from; i <= n; i++ {
+			if err := f.tmpl.Execute(&buf, newArity(f.name, i)); err != nil {
+				return nil, fmt.Errorf("%s%d: %w", f.name, i, err)
+			}
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// Template data for a single member of a family
+type arity struct {
+	N    int
+	Name string // family member name, e.g. Compose3
+
+	Args  []string // argument names: a, b, c, ...
+	Types []string // type parameters for N arguments and the result
+	Funcs []string // type parameters for function arguments
+	Vars  []string // function argument names: f, g, h, ...
+
+	Compose []link // arguments of Compose: f(g(h(x)))
+	Pipe    []link // arguments of Pipe: h(g(f(x)))
+}
+
+// Function argument of Compose or Pipe
+type link struct {
+	Var, Func string
+	In, Out   string
+}
+
+func newArity(family string, n int) arity {
+	a := arity{N: n, Name: family + fmt.Sprint(n)}
+	// Families with pairs have no numeric suffix for the smallest arity,
+	// e.g. Tuple and Zip, NoError
+	if n == 2 && (family == "Tuple" || family == "Zip") ||
+		n == 1 && family == "NoError" {
+		a.Name = family
+	}
+
+	// "f" is reserved for the function argument
+	a.Args = pick("abcdeghijklmnopqrstuvwxyz", n)
+	a.Vars = pick("fghijklmnopqrstuvwxyz", n)
+
+	// Function type parameters follow value ones, but never before tF
+	for i := 0; i <= n; i++ {
+		a.Types = append(a.Types, "t"+string(rune('A'+i)))
+	}
+	for i := max(5, n+1); len(a.Funcs) < n; i++ {
+		a.Funcs = append(a.Funcs, "t"+string(rune('A'+i)))
+	}
+
+	for i := 0; i < n; i++ {
+		a.Pipe = append(a.Pipe, link{
+			Var: a.Vars[i], Func: a.Funcs[i],
+			In: a.Types[i], Out: a.Types[i+1],
+		})
+		a.Compose = append(a.Compose, link{
+			Var: a.Vars[i], Func: a.Funcs[i],
+			In: a.Types[n-1-i], Out: a.Types[n-i],
+		})
+	}
+	return a
+}
+
+func pick(letters string, n int) []string {
+	return strings.Split(letters[:n], "")
+}
+
+type family struct {
+	name string
+	from int
+	tmpl *template.Template
+}
+
+var funcs = template.FuncMap{
+	"join": strings.Join,
+	"inc": func(i int) int {
+		return i + 1
+	},
+	// Pairs arguments with types: "a tA, b tB"
+	"params": func(args, types []string) string {
+		ps := make([]string, len(args))
+		for i, a := range args {
+			ps[i] = a + " " + types[i]
+		}
+		return strings.Join(ps, ", ")
+	},
+	// Curried function type taking arguments from i: "func(tB) func(tC) tD"
+	"curried": func(types []string, i int) string {
+		var b strings.Builder
+		for _, t := range types[i : len(types)-1] {
+			fmt.Fprintf(&b, "func(%s) ", t)
+		}
+		b.WriteString(types[len(types)-1])
+		return b.String()
+	},
+	"last": func(s []string) string {
+		return s[len(s)-1]
+	},
+	"reverse": func(s []string) []string {
+		r := make([]string, len(s))
+		for i, e := range s {
+			r[len(s)-1-i] = e
+		}
+		return r
+	},
+}
+
+func tmpl(src string) *template.Template {
+	return template.Must(template.New("").Funcs(funcs).Parse(src))
+}
+
+var families = []family{
+	{"Tuple", 2, tmpl(`
+type {{.Name}}[{{join (slice .Types 0 .N) ", "}} any] struct {
+{{- range $i, $t := slice .Types 0 .N}}
+	{{slice $t 1}} {{$t}}
+{{- end}}
+}
+`)},
+
+	{"Zip", 2, tmpl(`
+{{$tuple := printf "Tuple%d" .N}}{{if eq .N 2}}{{$tuple = "Tuple"}}{{end -}}
+{{$res := printf "%s[%s]" $tuple (join (slice .Types 0 .N) ", ") -}}
+// Zips slices up to the length of the shortest one
+func {{.Name}}[{{join (slice .Types 0 .N) ", "}} any](
+{{- range $i, $a := .Args}}{{if $i}}, {{end}}{{$a}} []{{index $.Types $i}}{{end -}}
+) []{{$res}} {
+	size := Minimum({{range $i, $a := .Args}}{{if $i}}, {{end}}len({{$a}}){{end}})
+	res := make([]{{$res}}, 0, size)
+	for idx := 0; idx < size; idx++ {
+		res = append(res, {{$res}}{
+			{{- range $i, $a := .Args}}{{if $i}}, {{end}}{{$a}}[idx]{{end -}}
+		})
+	}
+	return res
+}
+`)},
+
+	{"Apply", 1, tmpl(`
+func {{.Name}}[
+	tA, tB any,
+	tF ~func(...tA) tB,
+](f tF) func({{join .Args ", "}} tA) tB {
+	return func({{join .Args ", "}} tA) tB {
+		return f({{join .Args ", "}})
+	}
+}
+`)},
+
+	{"Lazy", 2, tmpl(`
+func {{.Name}}[
+	{{join .Types ", "}} any,
+	{{index .Funcs 0}} ~func({{join (slice .Types 0 .N) ", "}}) {{last .Types}},
+](f {{index .Funcs 0}}) func(a tA) {{curried .Types 1}} {
+{{- range $i, $a := .Args}}
+	return func({{$a}} {{index $.Types $i}}) {{curried $.Types (inc $i)}} {
+{{- end}}
+	return f({{join .Args ", "}})
+{{- range .Args}}
+	}
+{{- end}}
+}
+`)},
+
+	{"Uncurry", 2, tmpl(`
+func {{.Name}}[
+	{{join .Types ", "}} any,
+	{{index .Funcs 0}} ~{{curried .Types 0}},
+](f {{index .Funcs 0}}) func({{join (slice .Types 0 .N) ", "}}) {{last .Types}} {
+	return func({{params .Args .Types}}) {{last .Types}} {
+		return f({{join .Args ")("}})
+	}
+}
+`)},
+
+	{"Partial", 2, tmpl(`
+// Binds the first argument of f
+func {{.Name}}[
+	{{join .Types ", "}} any,
+	{{index .Funcs 0}} ~func({{join (slice .Types 0 .N) ", "}}) {{last .Types}},
+](f {{index .Funcs 0}}, a tA) func({{join (slice .Types 1 .N) ", "}}) {{last .Types}} {
+	return func({{params (slice .Args 1) (slice .Types 1)}}) {{last .Types}} {
+		return f({{join .Args ", "}})
+	}
+}
+`)},
+
+	{"Compose", 2, tmpl(`
+// {{.Name}}({{join .Vars ", "}})(x) == {{join .Vars "("}}(x{{range .Vars}}){{end}}
+func {{.Name}}[
+	{{join .Types ", "}} any,
+{{- range .Compose}}
+	{{.Func}} ~func({{.In}}) {{.Out}},
+{{- end}}
+](
+{{- range $i, $l := .Compose}}{{if $i}}, {{end}}{{.Var}} {{.Func}}{{end -}}
+) func(tA) {{last .Types}} {
+	return func(a tA) {{last .Types}} {
+		return {{join .Vars "("}}(a{{range .Vars}}){{end}}
+	}
+}
+`)},
+
+	{"Pipe", 2, tmpl(`
+{{$rev := reverse .Vars -}}
+// {{.Name}}({{join .Vars ", "}})(x) == {{join $rev "("}}(x{{range .Vars}}){{end}}
+func {{.Name}}[
+	{{join .Types ", "}} any,
+{{- range .Pipe}}
+	{{.Func}} ~func({{.In}}) {{.Out}},
+{{- end}}
+](
+{{- range $i, $l := .Pipe}}{{if $i}}, {{end}}{{.Var}} {{.Func}}{{end -}}
+) func(tA) {{last .Types}} {
+	return func(a tA) {{last .Types}} {
+		return {{join $rev "("}}(a{{range .Vars}}){{end}}
+	}
+}
+`)},
+
+	{"Memoize", 2, tmpl(`
+{{$tuple := printf "Tuple%d" .N}}{{if eq .N 2}}{{$tuple = "Tuple"}}{{end -}}
+{{$key := printf "%s[%s]" $tuple (join (slice .Types 0 .N) ", ") -}}
+// See Memoize()
+func {{.Name}}[
+	{{join (slice .Types 0 .N) ", "}} comparable,
+	{{last .Types}} any,
+	{{index .Funcs 0}} ~func({{join (slice .Types 0 .N) ", "}}) {{last .Types}},
+](f {{index .Funcs 0}}, cache Cache[{{$key}}, {{last .Types}}]) func({{join (slice .Types 0 .N) ", "}}) {{last .Types}} {
+	memo := Memoize(func(key {{$key}}) {{last .Types}} {
+		return f({{range $i, $t := slice .Types 0 .N}}{{if $i}}, {{end}}key.{{slice $t 1}}{{end}})
+	}, cache)
+	return func({{params .Args .Types}}) {{last .Types}} {
+		return memo({{$key}}{ {{- join .Args ", " -}} })
+	}
+}
+`)},
+
+	{"NoError", 1, tmpl(`
+{{$types := slice .Types 0 .N -}}
+func {{.Name}}[{{join $types ", "}} any]({{params .Args $types}}, err error) {{if eq .N 1}}tA{{else}}({{join $types ", "}}){{end}} {
+	MustNil(err)
+	return {{join .Args ", "}}
+}
+`)},
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedUpToDate(t *testing.T) {
+	src, err := os.ReadFile("../../fp_gen.go")
+	require.NoError(t, err)
+
+	n, err := ParseHeader(src)
+	require.NoError(t, err)
+
+	gen, err := Generate("fp", n)
+	require.NoError(t, err)
+	require.Equal(t, string(gen), string(src),
+		"fp_gen.go is stale, run go generate")
+}
+
+func TestGenerateBounds(t *testing.T) {
+	_, err := Generate("fp", 1)
+	require.Error(t, err)
+
+	_, err = Generate("fp", MaxArity+1)
+	require.Error(t, err)
+
+	src, err := Generate("fp", MaxArity)
+	require.NoError(t, err)
+
+	n, err := ParseHeader(src)
+	require.NoError(t, err)
+	require.Equal(t, MaxArity, n)
+}
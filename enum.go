@@ -0,0 +1,157 @@
+package fp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+//////////
+/// Enumerations
+
+var ErrUnknownEnum = errors.New("unknown enum value")
+
+// Registry of enum values and their names kept in declaration order.
+// Marshal helpers are meant to back the methods of the enum type itself:
+//
+//	var colors = fp.NewEnumeration(Color.String, Red, Green, Blue)
+//
+//	func (c Color) MarshalText() ([]byte, error) {
+//		return colors.MarshalTextOf(c)
+//	}
+//
+//	func (c *Color) UnmarshalText(b []byte) error {
+//		return colors.UnmarshalTextTo(b, c)
+//	}
+type Enumeration[tA comparable] struct {
+	values []tA
+	names  []string
+	index  map[tA]int
+	byName map[string]int
+}
+
+// Registers values with names provided by name; panics with MustError if
+// values or names aren't unique, see Enum()
+func NewEnumeration[
+	tA comparable,
+	tF ~func(tA) string,
+](name tF, values ...tA) *Enumeration[tA] {
+	e := &Enumeration[tA]{
+		values: Enum(values...),
+		names:  Enum(Map(name, values...)...),
+		index:  make(map[tA]int, len(values)),
+		byName: make(map[string]int, len(values)),
+	}
+	for i, v := range e.values {
+		e.index[v] = i
+		e.byName[e.names[i]] = i
+	}
+	return e
+}
+
+// Returns values in declaration order
+func (e *Enumeration[tA]) Values() []tA {
+	return Concat(e.values)
+}
+
+// Returns names in declaration order
+func (e *Enumeration[tA]) Names() []string {
+	return Concat(e.names)
+}
+
+func (e *Enumeration[tA]) Contains(v tA) bool {
+	_, ok := e.index[v]
+	return ok
+}
+
+// Returns position of v in declaration order, -1 if v is unknown
+func (e *Enumeration[tA]) Index(v tA) int {
+	i, ok := e.index[v]
+	return Cond(-1, i)(ok)
+}
+
+func (e *Enumeration[tA]) Name(v tA) (name string, ok bool) {
+	i, ok := e.index[v]
+	if !ok {
+		return "", false
+	}
+	return e.names[i], true
+}
+
+// Returns name of v or a placeholder for unknown values
+func (e *Enumeration[tA]) String(v tA) string {
+	if name, ok := e.Name(v); ok {
+		return name
+	}
+	return fmt.Sprintf("%T(%s)", v, rawString(v))
+}
+
+// Formats v by its underlying kind. Methods of v aren't called, since they
+// are likely to delegate back to Enumeration, e.g. String().
+func rawString(v any) string {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	case reflect.String:
+		return strconv.Quote(rv.String())
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+func (e *Enumeration[tA]) Parse(name string) (tA, error) {
+	i, ok := e.byName[name]
+	if !ok {
+		return Zero[tA](), fmt.Errorf("%w: %q", ErrUnknownEnum, name)
+	}
+	return e.values[i], nil
+}
+
+func (e *Enumeration[tA]) MustParse(name string) tA {
+	v, err := e.Parse(name)
+	return NoErrorf(v, err, "parsing %T", v)
+}
+
+func (e *Enumeration[tA]) MarshalTextOf(v tA) ([]byte, error) {
+	name, ok := e.Name(v)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEnum, e.String(v))
+	}
+	return []byte(name), nil
+}
+
+// Stores value named b into v; v is left intact on failure
+func (e *Enumeration[tA]) UnmarshalTextTo(b []byte, v *tA) error {
+	res, err := e.Parse(string(b))
+	if err != nil {
+		return err
+	}
+	*v = res
+	return nil
+}
+
+func (e *Enumeration[tA]) MarshalJSONOf(v tA) ([]byte, error) {
+	name, err := e.MarshalTextOf(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(name))
+}
+
+func (e *Enumeration[tA]) UnmarshalJSONTo(b []byte, v *tA) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		return err
+	}
+	return e.UnmarshalTextTo([]byte(name), v)
+}
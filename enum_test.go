@@ -0,0 +1,125 @@
+package fp
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testColor int
+
+const (
+	testRed testColor = iota + 1
+	testGreen
+	testBlue
+)
+
+func (c testColor) name() string {
+	switch c {
+	case testRed:
+		return "red"
+	case testGreen:
+		return "green"
+	case testBlue:
+		return "blue"
+	}
+	return ""
+}
+
+var testColors = NewEnumeration(testColor.name, testRed, testGreen, testBlue)
+
+func (c testColor) MarshalJSON() ([]byte, error) {
+	return testColors.MarshalJSONOf(c)
+}
+
+func (c *testColor) UnmarshalJSON(b []byte) error {
+	return testColors.UnmarshalJSONTo(b, c)
+}
+
+func TestEnumeration(t *testing.T) {
+	e := testColors
+
+	{
+		require.Equal(t, []testColor{testRed, testGreen, testBlue}, e.Values())
+		require.Equal(t, []string{"red", "green", "blue"}, e.Names())
+		require.True(t, e.Contains(testBlue))
+		require.False(t, e.Contains(0))
+		require.Equal(t, 1, e.Index(testGreen))
+		require.Equal(t, -1, e.Index(7))
+		require.Equal(t, "green", e.String(testGreen))
+		require.Equal(t, "fp.testColor(7)", e.String(7))
+	}
+
+	{
+		c, err := e.Parse("blue")
+		require.NoError(t, err)
+		require.Equal(t, testBlue, c)
+
+		_, err = e.Parse("pink")
+		require.ErrorIs(t, err, ErrUnknownEnum)
+
+		require.Equal(t, testRed, e.MustParse("red"))
+		me := recoverMust(func() { e.MustParse("pink") })
+		require.ErrorIs(t, me, ErrUnknownEnum)
+	}
+
+	{
+		b, err := json.Marshal([]testColor{testRed, testBlue})
+		require.NoError(t, err)
+		require.Equal(t, `["red","blue"]`, string(b))
+
+		var cs []testColor
+		require.NoError(t, json.Unmarshal(b, &cs))
+		require.Equal(t, []testColor{testRed, testBlue}, cs)
+
+		_, err = json.Marshal(testColor(7))
+		require.ErrorIs(t, err, ErrUnknownEnum)
+
+		c := testGreen
+		require.ErrorIs(t, json.Unmarshal([]byte(`"pink"`), &c), ErrUnknownEnum)
+		require.Equal(t, testGreen, c)
+	}
+
+	{
+		_, err := e.MarshalTextOf(7)
+		require.ErrorIs(t, err, ErrUnknownEnum)
+
+		var c testColor
+		require.NoError(t, e.UnmarshalTextTo([]byte("green"), &c))
+		require.Equal(t, testGreen, c)
+	}
+
+	require.Panics(t, func() {
+		NewEnumeration(testColor.name, testRed, testGreen, testRed)
+	})
+	require.Panics(t, func() {
+		NewEnumeration(func(testColor) string { return "same" }, testRed, testGreen)
+	})
+}
+
+// Enum type delegating String() to its Enumeration, as it's meant to be used
+type testLevel int
+
+var testLevels = NewEnumeration(func(l testLevel) string {
+	return [...]string{"low", "high"}[l]
+}, 0, 1)
+
+func (l testLevel) String() string {
+	return testLevels.String(l)
+}
+
+func (l testLevel) MarshalText() ([]byte, error) {
+	return testLevels.MarshalTextOf(l)
+}
+
+func TestEnumerationDelegatingString(t *testing.T) {
+	require.Equal(t, "high", testLevel(1).String())
+	require.Equal(t, "fp.testLevel(7)", testLevel(7).String())
+	require.Equal(t, "high", fmt.Sprint(testLevel(1)))
+
+	_, err := testLevel(7).MarshalText()
+	require.ErrorIs(t, err, ErrUnknownEnum)
+	require.EqualError(t, err, "unknown enum value: fp.testLevel(7)")
+}
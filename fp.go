@@ -28,7 +28,10 @@ Checks and validations
 
 package fp
 
+//go:generate go run ./cmd/fpgen -n 8 -o fp_gen.go
+
 import (
+	"context"
 	"fmt"
 	"math/rand"
 )
@@ -118,25 +121,6 @@ func Count[
 	return i
 }
 
-type Tuple[tA, tB any] struct {
-	A tA
-	B tB
-}
-
-func Zip[tA, tB any](a []tA, b []tB) []Tuple[tA, tB] {
-	if la, lb := len(a), len(b); la < lb {
-		return ReduceIndex(
-			func(acc []Tuple[tA, tB], e tA, i int) []Tuple[tA, tB] {
-				return append(acc, Tuple[tA, tB]{e, b[i]})
-			}, make([]Tuple[tA, tB], 0, la), a...)
-	} else {
-		return ReduceIndex(
-			func(acc []Tuple[tA, tB], e tB, i int) []Tuple[tA, tB] {
-				return append(acc, Tuple[tA, tB]{a[i], e})
-			}, make([]Tuple[tA, tB], 0, lb), b...)
-	}
-}
-
 func FindIndex[
 	tA any,
 	tF ~func(tA, int) bool,
@@ -251,6 +235,10 @@ func Not[
 	}
 }
 
+// Comparisons below return plain predicates, which fit Filter(), Find() and
+// alike but can't describe themselves; package pred has their described
+// counterparts for MustBe() and error messages.
+
 // b == a
 func Eq[tA comparable](a tA) func(b tA) bool {
 	return func(b tA) bool {
@@ -318,7 +306,7 @@ func IsEmpty[
 }
 
 func MIsEmpty[
-	tM map[tA]tB,
+	tM ~map[tA]tB,
 	tA comparable,
 	tB any,
 ](m tM) bool {
@@ -342,7 +330,7 @@ func MinimumIndex[tA Ordered](a ...tA) (min tA, indx int) {
 	min = a[0]
 	for i, e := range a[1:] {
 		if e < min {
-			indx = i
+			indx = i + 1
 			min = e
 		}
 	}
@@ -364,7 +352,7 @@ func MaximumIndex[tA Ordered](a ...tA) (max tA, indx int) {
 	max = a[0]
 	for i, e := range a[1:] {
 		if e > max {
-			indx = i
+			indx = i + 1
 			max = e
 		}
 	}
@@ -377,10 +365,105 @@ func Maximum[tA Ordered](a ...tA) tA {
 	return max
 }
 
+// Returns index of the first minimal element, -1 if no arguments are provided
+func ArgMin[tA Ordered](a ...tA) int {
+	_, i := MinimumIndex(a...)
+	return i
+}
+
+// Returns index of the first maximal element, -1 if no arguments are provided
+func ArgMax[tA Ordered](a ...tA) int {
+	_, i := MaximumIndex(a...)
+	return i
+}
+
+// Tie-breaking policy for extremes that occur more than once
+type Tie int
+
+const (
+	TieFirst Tie = iota // the first of equal elements wins
+	TieLast             // the last of equal elements wins
+)
+
+// Returns minimal element with respect to c and its index;
+// zero value and -1 if no arguments are provided
+func MinWith[tA any](c Comparator[tA], tie Tie, a ...tA) (min tA, indx int) {
+	if len(a) == 0 {
+		return Zero[tA](), -1
+	}
+
+	min = a[0]
+	for i, e := range a[1:] {
+		if r := c(e, min); r < 0 || r == 0 && tie == TieLast {
+			indx = i + 1
+			min = e
+		}
+	}
+	return min, indx
+}
+
+// Returns maximal element with respect to c and its index;
+// zero value and -1 if no arguments are provided
+func MaxWith[tA any](c Comparator[tA], tie Tie, a ...tA) (max tA, indx int) {
+	return MinWith(c.Reversed(), tie, a...)
+}
+
+// Finds both extremes in a single pass; indexes are -1 if no arguments are
+// provided
+func MinMaxWith[tA any](c Comparator[tA], tie Tie, a ...tA) (
+	min, max tA, minIndx, maxIndx int,
+) {
+	if len(a) == 0 {
+		return Zero[tA](), Zero[tA](), -1, -1
+	}
+
+	min, max = a[0], a[0]
+	for i, e := range a[1:] {
+		if r := c(e, min); r < 0 || r == 0 && tie == TieLast {
+			minIndx = i + 1
+			min = e
+		}
+		if r := c(e, max); r > 0 || r == 0 && tie == TieLast {
+			maxIndx = i + 1
+			max = e
+		}
+	}
+	return min, max, minIndx, maxIndx
+}
+
+// Returns element with minimal key and its index;
+// zero value and -1 if no arguments are provided
+func MinBy[
+	tA any,
+	tB Ordered,
+	tF ~func(tA) tB,
+](key tF, tie Tie, a ...tA) (tA, int) {
+	return MinWith(Comparing(key), tie, a...)
+}
+
+// Returns element with maximal key and its index;
+// zero value and -1 if no arguments are provided
+func MaxBy[
+	tA any,
+	tB Ordered,
+	tF ~func(tA) tB,
+](key tF, tie Tie, a ...tA) (tA, int) {
+	return MaxWith(Comparing(key), tie, a...)
+}
+
+// See MinMaxWith()
+func MinMaxBy[
+	tA any,
+	tB Ordered,
+	tF ~func(tA) tB,
+](key tF, tie Tie, a ...tA) (min, max tA, minIndx, maxIndx int) {
+	return MinMaxWith(Comparing(key), tie, a...)
+}
+
 // Returns duplicate value dup and its index if there's any,
 // zero value and index -1 otherwise
 // NOTE: More efficient implementations are possible if tA is Ordered or
-// if you're willing to modify original slice.
+// if you're willing to modify original slice; see NoDupsSorted().
 func FindDupsIndex[tA comparable](a ...tA) (dup tA, index int) {
 	d := map[tA]struct{}{}
 	for i, v := range a {
@@ -406,6 +489,94 @@ func NoDups[tA comparable](a ...tA) bool {
 	return !ok
 }
 
+// Returns true if no duplicates are present in sorted a
+func NoDupsSorted[tA Ordered](a ...tA) bool {
+	for i := 1; i < len(a); i++ {
+		if a[i] == a[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes duplicates keeping the first occurrence of every value in the
+// original order
+func Distinct[tA comparable](a ...tA) []tA {
+	return DistinctBy(func(e tA) tA {
+		return e
+	}, a...)
+}
+
+// Removes elements with duplicate keys keeping the first occurrence of every
+// key in the original order
+func DistinctBy[
+	tA any,
+	tB comparable,
+	tF ~func(tA) tB,
+](key tF, a ...tA) []tA {
+	seen := make(map[tB]struct{}, len(a))
+	return Filter(func(e tA) bool {
+		k := key(e)
+		if _, ok := seen[k]; ok {
+			return false
+		}
+		seen[k] = struct{}{}
+		return true
+	}, a...)
+}
+
+// Collapses runs of equal adjacent elements into one, like uniq(1)
+func DistinctAdjacent[tA comparable](a ...tA) []tA {
+	return FilterIndex(func(e tA, i int) bool {
+		return i == 0 || a[i-1] != e
+	}, a...)
+}
+
+// Removes duplicates from sorted a without allocating a lookup table
+func DistinctSorted[tA Ordered](a ...tA) []tA {
+	return DistinctAdjacent(a...)
+}
+
+// Group of elements sharing the same key
+type DupGroup[tK comparable, tA any] struct {
+	Key     tK
+	Values  []tA
+	Indexes []int
+}
+
+// Returns every group of elements sharing the same key with more than one
+// member. Groups are ordered by the first occurrence of their key.
+func DuplicatesBy[
+	tA any,
+	tB comparable,
+	tF ~func(tA) tB,
+](key tF, a ...tA) []DupGroup[tB, tA] {
+	var groups []DupGroup[tB, tA]
+	pos := map[tB]int{}
+	for i, e := range a {
+		k := key(e)
+		j, ok := pos[k]
+		if !ok {
+			j = len(groups)
+			pos[k] = j
+			groups = append(groups, DupGroup[tB, tA]{Key: k})
+		}
+		groups[j].Values = append(groups[j].Values, e)
+		groups[j].Indexes = append(groups[j].Indexes, i)
+	}
+
+	return Filter(func(g DupGroup[tB, tA]) bool {
+		return len(g.Indexes) > 1
+	}, groups...)
+}
+
+// See DuplicatesBy()
+func Duplicates[tA comparable](a ...tA) []DupGroup[tA, tA] {
+	return DuplicatesBy(func(e tA) tA {
+		return e
+	}, a...)
+}
+
 //////////
 /// Arithmetics
 
@@ -446,6 +617,13 @@ func IsEven[tA IntegerNumber](n tA) bool {
 	return n%2 == 0
 }
 
+// Clamp(lo, hi)(x) limits x to [lo, hi]
+func Clamp[tA Ordered](lo, hi tA) func(tA) tA {
+	return func(v tA) tA {
+		return Maximum(lo, Minimum(v, hi))
+	}
+}
+
 //////////
 /// Maps
 
@@ -473,6 +651,32 @@ func MReduceZ[
 	return MReduce(f, Zero[tC](), m)
 }
 
+// Like MReduce(), but traverses keys in ascending order
+func MReduceSorted[
+	tF ~func(tC, tA, tB) tC,
+	tM ~map[tA]tB,
+	tA Ordered,
+	tB, tC any,
+](f tF, z tC, m tM) tC {
+	return MReduceBy(Compare[tA], f, z, m)
+}
+
+// Like MReduce(), but traverses keys in order defined by c. Keys come from
+// random map traversal, so c must be a total order on them: keys it deems
+// equal are traversed in unspecified order, which no sort can stabilize.
+func MReduceBy[
+	tF ~func(tC, tA, tB) tC,
+	tM ~map[tA]tB,
+	tA comparable,
+	tB, tC any,
+](c Comparator[tA], f tF, z tC, m tM) tC {
+	keys := MKeys(m)
+	SortWith_(c, keys)
+	return Reduce(func(acc tC, k tA) tC {
+		return f(acc, k, m[k])
+	}, z, keys...)
+}
+
 func MMapK[
 	tF ~func(tA, tB) tC,
 	tM ~map[tA]tB,
@@ -494,6 +698,32 @@ func MMap[
 	}, m)
 }
 
+// Like MMapK(), but the result follows ascending order of keys
+func MMapKSorted[
+	tF ~func(tA, tB) tC,
+	tM ~map[tA]tB,
+	tA Ordered,
+	tB, tC any,
+](f tF, m tM) []tC {
+	return MReduceSorted(func(acc []tC, k tA, v tB) []tC {
+		return append(acc, f(k, v))
+	}, make([]tC, 0, len(m)), m)
+}
+
+// Like MMap(), but the result follows ascending order of keys
+func MMapSorted[
+	tF ~func(tB) tC,
+	tM ~map[tA]tB,
+	tA Ordered,
+	tB, tC any,
+](f tF, m tM) []tC {
+	return MMapKSorted(func(_ tA, v tB) tC {
+		return f(v)
+	}, m)
+}
+
+// Maps both keys and values. If f maps several keys onto the same key, an
+// arbitrary one of them wins; see MMapMKWith() and MMapMKStrict().
 func MMapMK[
 	tF ~func(tA, tC) (tB, tD),
 	tM ~map[tA]tC,
@@ -508,6 +738,125 @@ func MMapMK[
 	}, make(map[tB]tD, len(m)), m)
 }
 
+// Two source keys mapped onto the same key
+type KeyCollision[tA, tB comparable] struct {
+	Key tB
+	Src [2]tA
+}
+
+func (c KeyCollision[tA, tB]) String() string {
+	return fmt.Sprintf("%v and %v -> %v", c.Src[0], c.Src[1], c.Key)
+}
+
+// Like MMapMK(), but resolve is called with the new key, the value stored so
+// far and the colliding value whenever several keys are mapped onto the same
+// key. Since maps are traversed in random order, resolve must not depend on
+// the order of its arguments; see MMapMKWithBy() otherwise.
+func MMapMKWith[
+	tF ~func(tA, tC) (tB, tD),
+	tR ~func(k tB, old, new tD) tD,
+	tM ~map[tA]tC,
+	tA, tB comparable,
+	tC, tD any,
+](f tF, resolve tR, m tM) map[tB]tD {
+	return MReduce(resolving(f, resolve),
+		make(map[tB]tD, len(m)), m)
+}
+
+// Like MMapMKWith(), but m is traversed in order of its keys defined by c,
+// so resolve receives colliding values in that order; see KeepFirst() and
+// KeepLast(). c must be a total order on keys, see MReduceBy().
+func MMapMKWithBy[
+	tF ~func(tA, tC) (tB, tD),
+	tR ~func(k tB, old, new tD) tD,
+	tM ~map[tA]tC,
+	tA, tB comparable,
+	tC, tD any,
+](c Comparator[tA], f tF, resolve tR, m tM) map[tB]tD {
+	return MReduceBy(c, resolving(f, resolve),
+		make(map[tB]tD, len(m)), m)
+}
+
+// Like MMapMKWithBy(), but keys of m are traversed in ascending order
+func MMapMKWithSorted[
+	tF ~func(tA, tC) (tB, tD),
+	tR ~func(k tB, old, new tD) tD,
+	tM ~map[tA]tC,
+	tA Ordered,
+	tB comparable,
+	tC, tD any,
+](f tF, resolve tR, m tM) map[tB]tD {
+	return MMapMKWithBy(Compare[tA], f, resolve, m)
+}
+
+// Reducer storing f(k, v) into acc and resolving collisions with resolve
+func resolving[
+	tF ~func(tA, tC) (tB, tD),
+	tR ~func(k tB, old, new tD) tD,
+	tA, tB comparable,
+	tC, tD any,
+](f tF, resolve tR) func(map[tB]tD, tA, tC) map[tB]tD {
+	return func(acc map[tB]tD, k tA, v tC) map[tB]tD {
+		nk, nv := f(k, v)
+		if old, ok := acc[nk]; ok {
+			nv = resolve(nk, old, nv)
+		}
+		acc[nk] = nv
+		return acc
+	}
+}
+
+// Collision policy keeping the value of the least source key, see
+// MMapMKWithBy()
+func KeepFirst[tK comparable, tV any](_ tK, old, _ tV) tV {
+	return old
+}
+
+// Collision policy keeping the value of the greatest source key, see
+// MMapMKWithBy()
+func KeepLast[tK comparable, tV any](_ tK, _, new tV) tV {
+	return new
+}
+
+// Like MMapMK(), but fails with MustError holding KeyCollision if several keys
+// are mapped onto the same key
+func MMapMKE[
+	tF ~func(tA, tC) (tB, tD),
+	tM ~map[tA]tC,
+	tA, tB comparable,
+	tC, tD any,
+](f tF, m tM) (map[tB]tD, error) {
+
+	res := make(map[tB]tD, len(m))
+	src := make(map[tB]tA, len(m))
+	for k, v := range m {
+		nk, nv := f(k, v)
+		if prev, ok := src[nk]; ok {
+			return nil, MustError{
+				Msg: "key collision",
+				Val: KeyCollision[tA, tB]{Key: nk, Src: [2]tA{prev, k}},
+			}.capture()
+		}
+		src[nk] = k
+		res[nk] = nv
+	}
+	return res, nil
+}
+
+// Like MMapMKE(), but panics
+func MMapMKStrict[
+	tF ~func(tA, tC) (tB, tD),
+	tM ~map[tA]tC,
+	tA, tB comparable,
+	tC, tD any,
+](f tF, m tM) map[tB]tD {
+	res, err := MMapMKE(f, m)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
 func MMapM[
 	tF ~func(tB) tC,
 	tM ~map[tA]tB,
@@ -519,58 +868,349 @@ func MMapM[
 	}, m)
 }
 
+func MFilter[
+	tF ~func(tA, tB) bool,
+	tM ~map[tA]tB,
+	tA comparable,
+	tB any,
+](p tF, m tM) tM {
+	return MReduce(func(acc tM, k tA, v tB) tM {
+		if p(k, v) {
+			acc[k] = v
+		}
+		return acc
+	}, make(tM), m)
+}
+
+func MKeys[
+	tM ~map[tA]tB,
+	tA comparable,
+	tB any,
+](m tM) []tA {
+	return MMapK(func(k tA, _ tB) tA {
+		return k
+	}, m)
+}
+
+func MValues[
+	tM ~map[tA]tB,
+	tA comparable,
+	tB any,
+](m tM) []tB {
+	return MMap(func(v tB) tB {
+		return v
+	}, m)
+}
+
+// Returns keys in ascending order
+func MSortedKeys[
+	tM ~map[tA]tB,
+	tA Ordered,
+	tB any,
+](m tM) []tA {
+	keys := MKeys(m)
+	Sort_(keys)
+	return keys
+}
+
+// Swaps keys and values. If several keys hold the same value, an arbitrary
+// one of them is kept.
+func MInvert[
+	tM ~map[tA]tB,
+	tA, tB comparable,
+](m tM) map[tB]tA {
+	return MMapMK(func(k tA, v tB) (tB, tA) {
+		return v, k
+	}, m)
+}
+
+// Merges maps left to right into a new map; conflict is called with the key,
+// the value accumulated so far and the new value whenever the key is already
+// present.
+func MMerge[
+	tF ~func(k tA, old, new tB) tB,
+	tM ~map[tA]tB,
+	tA comparable,
+	tB any,
+](conflict tF, ms ...tM) tM {
+	return Reduce(func(acc tM, m tM) tM {
+		for k, v := range m {
+			if old, ok := acc[k]; ok {
+				v = conflict(k, old, v)
+			}
+			acc[k] = v
+		}
+		return acc
+	}, make(tM), ms...)
+}
+
+// Returns a new map holding only the given keys
+func MPick[
+	tM ~map[tA]tB,
+	tA comparable,
+	tB any,
+](m tM, keys ...tA) tM {
+	return Reduce(func(acc tM, k tA) tM {
+		if v, ok := m[k]; ok {
+			acc[k] = v
+		}
+		return acc
+	}, make(tM, len(keys)), keys...)
+}
+
+// Returns a new map without the given keys
+func MOmit[
+	tM ~map[tA]tB,
+	tA comparable,
+	tB any,
+](m tM, keys ...tA) tM {
+	omit := Reduce(func(acc map[tA]bool, k tA) map[tA]bool {
+		acc[k] = true
+		return acc
+	}, make(map[tA]bool, len(keys)), keys...)
+
+	return MFilter(func(k tA, _ tB) bool {
+		return !omit[k]
+	}, m)
+}
+
+// Splits m into entries satisfying p and the rest
+func MPartition[
+	tF ~func(tA, tB) bool,
+	tM ~map[tA]tB,
+	tA comparable,
+	tB any,
+](p tF, m tM) (yes, no tM) {
+	yes, no = make(tM), make(tM)
+	for k, v := range m {
+		if p(k, v) {
+			yes[k] = v
+		} else {
+			no[k] = v
+		}
+	}
+	return yes, no
+}
+
+// Returns an arbitrary entry satisfying p
+func MFind[
+	tF ~func(tA, tB) bool,
+	tM ~map[tA]tB,
+	tA comparable,
+	tB any,
+](p tF, m tM) (k tA, v tB, ok bool) {
+	for k, v := range m {
+		if p(k, v) {
+			return k, v, true
+		}
+	}
+	return k, v, false
+}
+
+func MAny[
+	tF ~func(tA, tB) bool,
+	tM ~map[tA]tB,
+	tA comparable,
+	tB any,
+](p tF, m tM) bool {
+	_, _, ok := MFind(p, m)
+	return ok
+}
+
+func MAll[
+	tF ~func(tA, tB) bool,
+	tM ~map[tA]tB,
+	tA comparable,
+	tB any,
+](p tF, m tM) bool {
+	_, _, ok := MFind(func(k tA, v tB) bool {
+		return !p(k, v)
+	}, m)
+	return !ok
+}
+
 //////////
 /// Checks and validations
 
 // Ensures uniqueness of all passed arguments
 func Enum[tA comparable](a ...tA) []tA {
 	dup, i := FindDupsIndex(a...)
-	Must(Eq(-1), fmt.Sprintf("duplicate value \"%v\"; index: %d", dup, i), i)
+	must(
+		Describe("== -1", Eq(-1)),
+		fmt.Sprintf("duplicate value \"%v\"; index: %d", dup, i),
+		i,
+	)
 	return a
 }
 
+// Panics with MustError if p doesn't hold for any of a. If p implements
+// Described, it's reported as MustError.Pred and its description is used
+// when exceptionMsg is empty; plain predicates, such as the ones returned by
+// Eq() or Gt(), can't describe themselves, see package pred for the
+// described counterparts.
 func Must[
 	tF ~func(v tA) bool,
 	tA any,
 ](p tF, exceptionMsg string, a ...tA) {
+	d, ok := any(p).(Described[tA])
+	if !ok {
+		d = Describe("", p)
+	}
+	must(d, exceptionMsg, a...)
+}
+
+// Predicate carrying a human-readable description, e.g. pred.Predicate
+type Described[tA any] interface {
+	Test(tA) bool
+	String() string
+}
+
+// Describes plain predicate p with desc
+func Describe[
+	tA any,
+	tF ~func(tA) bool,
+](desc string, p tF) Described[tA] {
+	return described[tA]{desc, p}
+}
+
+type described[tA any] struct {
+	desc string
+	test func(tA) bool
+}
+
+func (d described[tA]) Test(v tA) bool {
+	return d.test(v)
+}
+
+func (d described[tA]) String() string {
+	return d.desc
+}
+
+// Like Must(), but the message is derived from the description of p, which
+// is also reported as MustError.Pred
+func MustBe[tA any](p Described[tA], a ...tA) {
+	must(p, "", a...)
+}
+
+// Adapts Described[tA] to values of any type; values of other types don't
+// satisfy it. Held by pointer, so that MustError stays comparable.
+type anyDescribed[tA any] struct {
+	p Described[tA]
+}
+
+func (d *anyDescribed[tA]) Test(v any) bool {
+	a, ok := v.(tA)
+	return ok && d.p.Test(a)
+}
+
+func (d *anyDescribed[tA]) String() string {
+	return d.p.String()
+}
+
+// Predicates with an empty description are treated as plain ones: they
+// aren't reported as MustError.Pred and msg isn't derived from them
+func must[tA any](p Described[tA], msg string, a ...tA) {
+	desc := p.String()
+	if msg == "" {
+		msg = desc
+	}
+	var pred Described[any]
+	if desc != "" {
+		pred = &anyDescribed[tA]{p}
+	}
 	for i, e := range a {
-		if !p(e) {
+		if !p.Test(e) {
 			panic(MustError{
-				Msg: exceptionMsg,
-				Val: e,
-				Pos: CondZ(&i)(len(a) > 1),
-			})
+				Msg:  msg,
+				Val:  e,
+				Pos:  CondZ(&i)(len(a) > 1),
+				Pred: pred,
+			}.capture())
 		}
 	}
 }
 
 func MustNonNil(a ...any) {
-	Must(IsNotZero, "nil value is not allowed", a...)
+	must(Describe("non-zero", IsNotZero[any]), "nil value is not allowed", a...)
 }
 
 func MustNil(a ...any) {
-	Must(IsZero, "nil value is mandatory", a...)
+	must(Describe("zero", IsZero[any]), "nil value is mandatory", a...)
 }
 
-func NoError[tA any](v tA, err error) tA {
-	MustNil(err)
+// Panics with MustError wrapping err if it's not nil. Unlike MustNil(), the
+// error message tells what failed.
+func Check(err error, msg string) {
+	check(nil, err, msg)
+}
+
+func Checkf(err error, format string, args ...any) {
+	if err != nil {
+		check(nil, err, fmt.Sprintf(format, args...))
+	}
+}
+
+// Like Check(), but attaches request ID found in ctx; see WithRequestID()
+func CheckCtx(ctx context.Context, err error, msg string) {
+	check(ctx, err, msg)
+}
+
+// Like NoError(), but the error message tells what failed
+func NoErrorMsg[tA any](v tA, err error, msg string) tA {
+	check(nil, err, msg)
 	return v
 }
 
-func NoError2[tA, tB any](a tA, b tB, err error) (tA, tB) {
-	MustNil(err)
-	return a, b
+func NoErrorf[tA any](v tA, err error, format string, args ...any) tA {
+	if err != nil {
+		check(nil, err, fmt.Sprintf(format, args...))
+	}
+	return v
+}
+
+// Like NoErrorMsg(), but attaches request ID found in ctx
+func NoErrorCtx[tA any](ctx context.Context, v tA, err error, msg string) tA {
+	check(ctx, err, msg)
+	return v
+}
+
+func check(ctx context.Context, err error, msg string) {
+	if err == nil {
+		return
+	}
+
+	e := MustError{Msg: msg, Val: err}
+	if ctx != nil {
+		e.RequestID, _ = RequestID(ctx)
+	}
+	panic(e.capture())
 }
 
-func NoError3[tA, tB, tC any](a tA, b tB, c tC, err error) (tA, tB, tC) {
-	MustNil(err)
-	return a, b, c
+type requestIDKey struct{}
+
+// Returns ctx carrying request ID picked up by CheckCtx() and NoErrorCtx()
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestID(ctx context.Context) (id string, ok bool) {
+	id, ok = ctx.Value(requestIDKey{}).(string)
+	return id, ok
 }
 
 type MustError struct {
-	Msg string
-	Val any
-	Pos *int // non-nil if multiple values were provided to Must()
+	Msg  string
+	Val  any
+	Pos  *int           // non-nil if multiple values were provided to Must()
+	Pred Described[any] // failed predicate if it describes itself
+
+	// Request ID attached by CheckCtx() and NoErrorCtx()
+	RequestID string
+	// Reason of the failure when Val isn't an error itself, e.g.
+	// ErrPathNotFound
+	Cause error
+
+	site *callSite
 }
 
 func (e MustError) Error() string {
@@ -579,10 +1219,19 @@ func (e MustError) Error() string {
 		pos = fmt.Sprintf(" at position %d", *e.Pos)
 	}
 
-	return fmt.Sprintf("failure for value \"%v\"%s: %s", e.Val, pos, e.Msg)
+	var rid string
+	if e.RequestID != "" {
+		rid = fmt.Sprintf(" [request %s]", e.RequestID)
+	}
+
+	return fmt.Sprintf("failure for value \"%v\"%s: %s%s",
+		e.Val, pos, e.Msg, rid)
 }
 
 func (e MustError) Unwrap() error {
+	if e.Cause != nil {
+		return e.Cause
+	}
 	err, ok := e.Val.(error)
 	if ok {
 		return err
@@ -692,97 +1341,47 @@ func Concat[tA any](a ...[]tA) []tA {
 //////////
 /// Applicators
 
-func Apply1[tA, tB any, tF ~func(...tA) tB](f tF) func(a tA) tB {
-	return func(a tA) tB {
-		return f(a)
-	}
-}
+// Apply, Lazy, Uncurry and Partial families, as well as Tuple, Zip, Compose,
+// Pipe and NoError ones, are generated by cmd/fpgen; see fp_gen.go.
 
-func Apply2[tA, tB any, tF ~func(...tA) tB](f tF) func(a, b tA) tB {
-	return func(a, b tA) tB {
-		return f(a, b)
-	}
-}
-
-func Apply3[
-	tA, tB any,
-	tF ~func(...tA) tB,
-](f tF) func(a, b, c tA) tB {
-	return func(a, b, c tA) tB {
-		return f(a, b, c)
-	}
-}
+//////////
+/// Composition
 
-func Apply4[
-	tA, tB any,
-	tF ~func(...tA) tB,
-](f tF) func(a, b, c, d tA) tB {
-	return func(a, b, c, d tA) tB {
-		return f(a, b, c, d)
-	}
+func Identity[tA any](a tA) tA {
+	return a
 }
 
-func Apply5[
-	tA, tB any,
-	tF ~func(...tA) tB,
-](f tF) func(a, b, c, d, e tA) tB {
-	return func(a, b, c, d, e tA) tB {
-		return f(a, b, c, d, e)
+// Returns function ignoring its argument and always returning a
+func Const[tA, tB any](a tA) func(tB) tA {
+	return func(tB) tA {
+		return a
 	}
 }
 
-func Lazy2[
+// Swaps arguments of f
+func Flip[
 	tA, tB, tC any,
 	tF ~func(tA, tB) tC,
-](f tF) func(a tA) func(tB) tC {
-	return func(a tA) func(tB) tC {
-		return func(b tB) tC {
-			return f(a, b)
-		}
-	}
-}
-
-func Lazy3[
-	tA, tB, tC, tD any,
-	tF ~func(tA, tB, tC) tD,
-](f tF) func(a tA) func(tB) func(tC) tD {
-	return func(a tA) func(tB) func(tC) tD {
-		return func(b tB) func(tC) tD {
-			return func(c tC) tD {
-				return f(a, b, c)
-			}
-		}
+](f tF) func(tB, tA) tC {
+	return func(b tB, a tA) tC {
+		return f(a, b)
 	}
 }
 
-func Lazy4[
-	tA, tB, tC, tD, tE any,
-	tF ~func(tA, tB, tC, tD) tE,
-](f tF) func(a tA) func(tB) func(tC) func(tD) tE {
-	return func(a tA) func(tB) func(tC) func(tD) tE {
-		return func(b tB) func(tC) func(tD) tE {
-			return func(c tC) func(tD) tE {
-				return func(d tD) tE {
-					return f(a, b, c, d)
-				}
-			}
-		}
-	}
+// Compose(f, g)(x) == f(g(x))
+func Compose[
+	tA, tB, tC any,
+	tF ~func(tB) tC,
+	tG ~func(tA) tB,
+](f tF, g tG) func(tA) tC {
+	return Compose2(f, g)
 }
 
-func Lazy5[
-	tA, tB, tC, tD, tE, tF any,
-	tG ~func(tA, tB, tC, tD, tE) tF,
-](f tG) func(a tA) func(tB) func(tC) func(tD) func(tE) tF {
-	return func(a tA) func(tB) func(tC) func(tD) func(tE) tF {
-		return func(b tB) func(tC) func(tD) func(tE) tF {
-			return func(c tC) func(tD) func(tE) tF {
-				return func(d tD) func(tE) tF {
-					return func(e tE) tF {
-						return f(a, b, c, d, e)
-					}
-				}
-			}
-		}
-	}
+// Pipe(f, g)(x) == g(f(x))
+func Pipe[
+	tA, tB, tC any,
+	tF ~func(tA) tB,
+	tG ~func(tB) tC,
+](f tF, g tG) func(tA) tC {
+	return Pipe2(f, g)
 }
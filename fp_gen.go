@@ -0,0 +1,787 @@
+// Code generated by fpgen -n 8. DO NOT EDIT.
+
+package fp
+
+type Tuple[tA, tB any] struct {
+	A tA
+	B tB
+}
+
+type Tuple3[tA, tB, tC any] struct {
+	A tA
+	B tB
+	C tC
+}
+
+type Tuple4[tA, tB, tC, tD any] struct {
+	A tA
+	B tB
+	C tC
+	D tD
+}
+
+type Tuple5[tA, tB, tC, tD, tE any] struct {
+	A tA
+	B tB
+	C tC
+	D tD
+	E tE
+}
+
+type Tuple6[tA, tB, tC, tD, tE, tF any] struct {
+	A tA
+	B tB
+	C tC
+	D tD
+	E tE
+	F tF
+}
+
+type Tuple7[tA, tB, tC, tD, tE, tF, tG any] struct {
+	A tA
+	B tB
+	C tC
+	D tD
+	E tE
+	F tF
+	G tG
+}
+
+type Tuple8[tA, tB, tC, tD, tE, tF, tG, tH any] struct {
+	A tA
+	B tB
+	C tC
+	D tD
+	E tE
+	F tF
+	G tG
+	H tH
+}
+
+// Zips slices up to the length of the shortest one
+func Zip[tA, tB any](a []tA, b []tB) []Tuple[tA, tB] {
+	size := Minimum(len(a), len(b))
+	res := make([]Tuple[tA, tB], 0, size)
+	for idx := 0; idx < size; idx++ {
+		res = append(res, Tuple[tA, tB]{a[idx], b[idx]})
+	}
+	return res
+}
+
+// Zips slices up to the length of the shortest one
+func Zip3[tA, tB, tC any](a []tA, b []tB, c []tC) []Tuple3[tA, tB, tC] {
+	size := Minimum(len(a), len(b), len(c))
+	res := make([]Tuple3[tA, tB, tC], 0, size)
+	for idx := 0; idx < size; idx++ {
+		res = append(res, Tuple3[tA, tB, tC]{a[idx], b[idx], c[idx]})
+	}
+	return res
+}
+
+// Zips slices up to the length of the shortest one
+func Zip4[tA, tB, tC, tD any](a []tA, b []tB, c []tC, d []tD) []Tuple4[tA, tB, tC, tD] {
+	size := Minimum(len(a), len(b), len(c), len(d))
+	res := make([]Tuple4[tA, tB, tC, tD], 0, size)
+	for idx := 0; idx < size; idx++ {
+		res = append(res, Tuple4[tA, tB, tC, tD]{a[idx], b[idx], c[idx], d[idx]})
+	}
+	return res
+}
+
+// Zips slices up to the length of the shortest one
+func Zip5[tA, tB, tC, tD, tE any](a []tA, b []tB, c []tC, d []tD, e []tE) []Tuple5[tA, tB, tC, tD, tE] {
+	size := Minimum(len(a), len(b), len(c), len(d), len(e))
+	res := make([]Tuple5[tA, tB, tC, tD, tE], 0, size)
+	for idx := 0; idx < size; idx++ {
+		res = append(res, Tuple5[tA, tB, tC, tD, tE]{a[idx], b[idx], c[idx], d[idx], e[idx]})
+	}
+	return res
+}
+
+// Zips slices up to the length of the shortest one
+func Zip6[tA, tB, tC, tD, tE, tF any](a []tA, b []tB, c []tC, d []tD, e []tE, g []tF) []Tuple6[tA, tB, tC, tD, tE, tF] {
+	size := Minimum(len(a), len(b), len(c), len(d), len(e), len(g))
+	res := make([]Tuple6[tA, tB, tC, tD, tE, tF], 0, size)
+	for idx := 0; idx < size; idx++ {
+		res = append(res, Tuple6[tA, tB, tC, tD, tE, tF]{a[idx], b[idx], c[idx], d[idx], e[idx], g[idx]})
+	}
+	return res
+}
+
+// Zips slices up to the length of the shortest one
+func Zip7[tA, tB, tC, tD, tE, tF, tG any](a []tA, b []tB, c []tC, d []tD, e []tE, g []tF, h []tG) []Tuple7[tA, tB, tC, tD, tE, tF, tG] {
+	size := Minimum(len(a), len(b), len(c), len(d), len(e), len(g), len(h))
+	res := make([]Tuple7[tA, tB, tC, tD, tE, tF, tG], 0, size)
+	for idx := 0; idx < size; idx++ {
+		res = append(res, Tuple7[tA, tB, tC, tD, tE, tF, tG]{a[idx], b[idx], c[idx], d[idx], e[idx], g[idx], h[idx]})
+	}
+	return res
+}
+
+// Zips slices up to the length of the shortest one
+func Zip8[tA, tB, tC, tD, tE, tF, tG, tH any](a []tA, b []tB, c []tC, d []tD, e []tE, g []tF, h []tG, i []tH) []Tuple8[tA, tB, tC, tD, tE, tF, tG, tH] {
+	size := Minimum(len(a), len(b), len(c), len(d), len(e), len(g), len(h), len(i))
+	res := make([]Tuple8[tA, tB, tC, tD, tE, tF, tG, tH], 0, size)
+	for idx := 0; idx < size; idx++ {
+		res = append(res, Tuple8[tA, tB, tC, tD, tE, tF, tG, tH]{a[idx], b[idx], c[idx], d[idx], e[idx], g[idx], h[idx], i[idx]})
+	}
+	return res
+}
+
+func Apply1[
+	tA, tB any,
+	tF ~func(...tA) tB,
+](f tF) func(a tA) tB {
+	return func(a tA) tB {
+		return f(a)
+	}
+}
+
+func Apply2[
+	tA, tB any,
+	tF ~func(...tA) tB,
+](f tF) func(a, b tA) tB {
+	return func(a, b tA) tB {
+		return f(a, b)
+	}
+}
+
+func Apply3[
+	tA, tB any,
+	tF ~func(...tA) tB,
+](f tF) func(a, b, c tA) tB {
+	return func(a, b, c tA) tB {
+		return f(a, b, c)
+	}
+}
+
+func Apply4[
+	tA, tB any,
+	tF ~func(...tA) tB,
+](f tF) func(a, b, c, d tA) tB {
+	return func(a, b, c, d tA) tB {
+		return f(a, b, c, d)
+	}
+}
+
+func Apply5[
+	tA, tB any,
+	tF ~func(...tA) tB,
+](f tF) func(a, b, c, d, e tA) tB {
+	return func(a, b, c, d, e tA) tB {
+		return f(a, b, c, d, e)
+	}
+}
+
+func Apply6[
+	tA, tB any,
+	tF ~func(...tA) tB,
+](f tF) func(a, b, c, d, e, g tA) tB {
+	return func(a, b, c, d, e, g tA) tB {
+		return f(a, b, c, d, e, g)
+	}
+}
+
+func Apply7[
+	tA, tB any,
+	tF ~func(...tA) tB,
+](f tF) func(a, b, c, d, e, g, h tA) tB {
+	return func(a, b, c, d, e, g, h tA) tB {
+		return f(a, b, c, d, e, g, h)
+	}
+}
+
+func Apply8[
+	tA, tB any,
+	tF ~func(...tA) tB,
+](f tF) func(a, b, c, d, e, g, h, i tA) tB {
+	return func(a, b, c, d, e, g, h, i tA) tB {
+		return f(a, b, c, d, e, g, h, i)
+	}
+}
+
+func Lazy2[
+	tA, tB, tC any,
+	tF ~func(tA, tB) tC,
+](f tF) func(a tA) func(tB) tC {
+	return func(a tA) func(tB) tC {
+		return func(b tB) tC {
+			return f(a, b)
+		}
+	}
+}
+
+func Lazy3[
+	tA, tB, tC, tD any,
+	tF ~func(tA, tB, tC) tD,
+](f tF) func(a tA) func(tB) func(tC) tD {
+	return func(a tA) func(tB) func(tC) tD {
+		return func(b tB) func(tC) tD {
+			return func(c tC) tD {
+				return f(a, b, c)
+			}
+		}
+	}
+}
+
+func Lazy4[
+	tA, tB, tC, tD, tE any,
+	tF ~func(tA, tB, tC, tD) tE,
+](f tF) func(a tA) func(tB) func(tC) func(tD) tE {
+	return func(a tA) func(tB) func(tC) func(tD) tE {
+		return func(b tB) func(tC) func(tD) tE {
+			return func(c tC) func(tD) tE {
+				return func(d tD) tE {
+					return f(a, b, c, d)
+				}
+			}
+		}
+	}
+}
+
+func Lazy5[
+	tA, tB, tC, tD, tE, tF any,
+	tG ~func(tA, tB, tC, tD, tE) tF,
+](f tG) func(a tA) func(tB) func(tC) func(tD) func(tE) tF {
+	return func(a tA) func(tB) func(tC) func(tD) func(tE) tF {
+		return func(b tB) func(tC) func(tD) func(tE) tF {
+			return func(c tC) func(tD) func(tE) tF {
+				return func(d tD) func(tE) tF {
+					return func(e tE) tF {
+						return f(a, b, c, d, e)
+					}
+				}
+			}
+		}
+	}
+}
+
+func Lazy6[
+	tA, tB, tC, tD, tE, tF, tG any,
+	tH ~func(tA, tB, tC, tD, tE, tF) tG,
+](f tH) func(a tA) func(tB) func(tC) func(tD) func(tE) func(tF) tG {
+	return func(a tA) func(tB) func(tC) func(tD) func(tE) func(tF) tG {
+		return func(b tB) func(tC) func(tD) func(tE) func(tF) tG {
+			return func(c tC) func(tD) func(tE) func(tF) tG {
+				return func(d tD) func(tE) func(tF) tG {
+					return func(e tE) func(tF) tG {
+						return func(g tF) tG {
+							return f(a, b, c, d, e, g)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func Lazy7[
+	tA, tB, tC, tD, tE, tF, tG, tH any,
+	tI ~func(tA, tB, tC, tD, tE, tF, tG) tH,
+](f tI) func(a tA) func(tB) func(tC) func(tD) func(tE) func(tF) func(tG) tH {
+	return func(a tA) func(tB) func(tC) func(tD) func(tE) func(tF) func(tG) tH {
+		return func(b tB) func(tC) func(tD) func(tE) func(tF) func(tG) tH {
+			return func(c tC) func(tD) func(tE) func(tF) func(tG) tH {
+				return func(d tD) func(tE) func(tF) func(tG) tH {
+					return func(e tE) func(tF) func(tG) tH {
+						return func(g tF) func(tG) tH {
+							return func(h tG) tH {
+								return f(a, b, c, d, e, g, h)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func Lazy8[
+	tA, tB, tC, tD, tE, tF, tG, tH, tI any,
+	tJ ~func(tA, tB, tC, tD, tE, tF, tG, tH) tI,
+](f tJ) func(a tA) func(tB) func(tC) func(tD) func(tE) func(tF) func(tG) func(tH) tI {
+	return func(a tA) func(tB) func(tC) func(tD) func(tE) func(tF) func(tG) func(tH) tI {
+		return func(b tB) func(tC) func(tD) func(tE) func(tF) func(tG) func(tH) tI {
+			return func(c tC) func(tD) func(tE) func(tF) func(tG) func(tH) tI {
+				return func(d tD) func(tE) func(tF) func(tG) func(tH) tI {
+					return func(e tE) func(tF) func(tG) func(tH) tI {
+						return func(g tF) func(tG) func(tH) tI {
+							return func(h tG) func(tH) tI {
+								return func(i tH) tI {
+									return f(a, b, c, d, e, g, h, i)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func Uncurry2[
+	tA, tB, tC any,
+	tF ~func(tA) func(tB) tC,
+](f tF) func(tA, tB) tC {
+	return func(a tA, b tB) tC {
+		return f(a)(b)
+	}
+}
+
+func Uncurry3[
+	tA, tB, tC, tD any,
+	tF ~func(tA) func(tB) func(tC) tD,
+](f tF) func(tA, tB, tC) tD {
+	return func(a tA, b tB, c tC) tD {
+		return f(a)(b)(c)
+	}
+}
+
+func Uncurry4[
+	tA, tB, tC, tD, tE any,
+	tF ~func(tA) func(tB) func(tC) func(tD) tE,
+](f tF) func(tA, tB, tC, tD) tE {
+	return func(a tA, b tB, c tC, d tD) tE {
+		return f(a)(b)(c)(d)
+	}
+}
+
+func Uncurry5[
+	tA, tB, tC, tD, tE, tF any,
+	tG ~func(tA) func(tB) func(tC) func(tD) func(tE) tF,
+](f tG) func(tA, tB, tC, tD, tE) tF {
+	return func(a tA, b tB, c tC, d tD, e tE) tF {
+		return f(a)(b)(c)(d)(e)
+	}
+}
+
+func Uncurry6[
+	tA, tB, tC, tD, tE, tF, tG any,
+	tH ~func(tA) func(tB) func(tC) func(tD) func(tE) func(tF) tG,
+](f tH) func(tA, tB, tC, tD, tE, tF) tG {
+	return func(a tA, b tB, c tC, d tD, e tE, g tF) tG {
+		return f(a)(b)(c)(d)(e)(g)
+	}
+}
+
+func Uncurry7[
+	tA, tB, tC, tD, tE, tF, tG, tH any,
+	tI ~func(tA) func(tB) func(tC) func(tD) func(tE) func(tF) func(tG) tH,
+](f tI) func(tA, tB, tC, tD, tE, tF, tG) tH {
+	return func(a tA, b tB, c tC, d tD, e tE, g tF, h tG) tH {
+		return f(a)(b)(c)(d)(e)(g)(h)
+	}
+}
+
+func Uncurry8[
+	tA, tB, tC, tD, tE, tF, tG, tH, tI any,
+	tJ ~func(tA) func(tB) func(tC) func(tD) func(tE) func(tF) func(tG) func(tH) tI,
+](f tJ) func(tA, tB, tC, tD, tE, tF, tG, tH) tI {
+	return func(a tA, b tB, c tC, d tD, e tE, g tF, h tG, i tH) tI {
+		return f(a)(b)(c)(d)(e)(g)(h)(i)
+	}
+}
+
+// Binds the first argument of f
+func Partial2[
+	tA, tB, tC any,
+	tF ~func(tA, tB) tC,
+](f tF, a tA) func(tB) tC {
+	return func(b tB) tC {
+		return f(a, b)
+	}
+}
+
+// Binds the first argument of f
+func Partial3[
+	tA, tB, tC, tD any,
+	tF ~func(tA, tB, tC) tD,
+](f tF, a tA) func(tB, tC) tD {
+	return func(b tB, c tC) tD {
+		return f(a, b, c)
+	}
+}
+
+// Binds the first argument of f
+func Partial4[
+	tA, tB, tC, tD, tE any,
+	tF ~func(tA, tB, tC, tD) tE,
+](f tF, a tA) func(tB, tC, tD) tE {
+	return func(b tB, c tC, d tD) tE {
+		return f(a, b, c, d)
+	}
+}
+
+// Binds the first argument of f
+func Partial5[
+	tA, tB, tC, tD, tE, tF any,
+	tG ~func(tA, tB, tC, tD, tE) tF,
+](f tG, a tA) func(tB, tC, tD, tE) tF {
+	return func(b tB, c tC, d tD, e tE) tF {
+		return f(a, b, c, d, e)
+	}
+}
+
+// Binds the first argument of f
+func Partial6[
+	tA, tB, tC, tD, tE, tF, tG any,
+	tH ~func(tA, tB, tC, tD, tE, tF) tG,
+](f tH, a tA) func(tB, tC, tD, tE, tF) tG {
+	return func(b tB, c tC, d tD, e tE, g tF) tG {
+		return f(a, b, c, d, e, g)
+	}
+}
+
+// Binds the first argument of f
+func Partial7[
+	tA, tB, tC, tD, tE, tF, tG, tH any,
+	tI ~func(tA, tB, tC, tD, tE, tF, tG) tH,
+](f tI, a tA) func(tB, tC, tD, tE, tF, tG) tH {
+	return func(b tB, c tC, d tD, e tE, g tF, h tG) tH {
+		return f(a, b, c, d, e, g, h)
+	}
+}
+
+// Binds the first argument of f
+func Partial8[
+	tA, tB, tC, tD, tE, tF, tG, tH, tI any,
+	tJ ~func(tA, tB, tC, tD, tE, tF, tG, tH) tI,
+](f tJ, a tA) func(tB, tC, tD, tE, tF, tG, tH) tI {
+	return func(b tB, c tC, d tD, e tE, g tF, h tG, i tH) tI {
+		return f(a, b, c, d, e, g, h, i)
+	}
+}
+
+// Compose2(f, g)(x) == f(g(x))
+func Compose2[
+	tA, tB, tC any,
+	tF ~func(tB) tC,
+	tG ~func(tA) tB,
+](f tF, g tG) func(tA) tC {
+	return func(a tA) tC {
+		return f(g(a))
+	}
+}
+
+// Compose3(f, g, h)(x) == f(g(h(x)))
+func Compose3[
+	tA, tB, tC, tD any,
+	tF ~func(tC) tD,
+	tG ~func(tB) tC,
+	tH ~func(tA) tB,
+](f tF, g tG, h tH) func(tA) tD {
+	return func(a tA) tD {
+		return f(g(h(a)))
+	}
+}
+
+// Compose4(f, g, h, i)(x) == f(g(h(i(x))))
+func Compose4[
+	tA, tB, tC, tD, tE any,
+	tF ~func(tD) tE,
+	tG ~func(tC) tD,
+	tH ~func(tB) tC,
+	tI ~func(tA) tB,
+](f tF, g tG, h tH, i tI) func(tA) tE {
+	return func(a tA) tE {
+		return f(g(h(i(a))))
+	}
+}
+
+// Compose5(f, g, h, i, j)(x) == f(g(h(i(j(x)))))
+func Compose5[
+	tA, tB, tC, tD, tE, tF any,
+	tG ~func(tE) tF,
+	tH ~func(tD) tE,
+	tI ~func(tC) tD,
+	tJ ~func(tB) tC,
+	tK ~func(tA) tB,
+](f tG, g tH, h tI, i tJ, j tK) func(tA) tF {
+	return func(a tA) tF {
+		return f(g(h(i(j(a)))))
+	}
+}
+
+// Compose6(f, g, h, i, j, k)(x) == f(g(h(i(j(k(x))))))
+func Compose6[
+	tA, tB, tC, tD, tE, tF, tG any,
+	tH ~func(tF) tG,
+	tI ~func(tE) tF,
+	tJ ~func(tD) tE,
+	tK ~func(tC) tD,
+	tL ~func(tB) tC,
+	tM ~func(tA) tB,
+](f tH, g tI, h tJ, i tK, j tL, k tM) func(tA) tG {
+	return func(a tA) tG {
+		return f(g(h(i(j(k(a))))))
+	}
+}
+
+// Compose7(f, g, h, i, j, k, l)(x) == f(g(h(i(j(k(l(x)))))))
+func Compose7[
+	tA, tB, tC, tD, tE, tF, tG, tH any,
+	tI ~func(tG) tH,
+	tJ ~func(tF) tG,
+	tK ~func(tE) tF,
+	tL ~func(tD) tE,
+	tM ~func(tC) tD,
+	tN ~func(tB) tC,
+	tO ~func(tA) tB,
+](f tI, g tJ, h tK, i tL, j tM, k tN, l tO) func(tA) tH {
+	return func(a tA) tH {
+		return f(g(h(i(j(k(l(a)))))))
+	}
+}
+
+// Compose8(f, g, h, i, j, k, l, m)(x) == f(g(h(i(j(k(l(m(x))))))))
+func Compose8[
+	tA, tB, tC, tD, tE, tF, tG, tH, tI any,
+	tJ ~func(tH) tI,
+	tK ~func(tG) tH,
+	tL ~func(tF) tG,
+	tM ~func(tE) tF,
+	tN ~func(tD) tE,
+	tO ~func(tC) tD,
+	tP ~func(tB) tC,
+	tQ ~func(tA) tB,
+](f tJ, g tK, h tL, i tM, j tN, k tO, l tP, m tQ) func(tA) tI {
+	return func(a tA) tI {
+		return f(g(h(i(j(k(l(m(a))))))))
+	}
+}
+
+// Pipe2(f, g)(x) == g(f(x))
+func Pipe2[
+	tA, tB, tC any,
+	tF ~func(tA) tB,
+	tG ~func(tB) tC,
+](f tF, g tG) func(tA) tC {
+	return func(a tA) tC {
+		return g(f(a))
+	}
+}
+
+// Pipe3(f, g, h)(x) == h(g(f(x)))
+func Pipe3[
+	tA, tB, tC, tD any,
+	tF ~func(tA) tB,
+	tG ~func(tB) tC,
+	tH ~func(tC) tD,
+](f tF, g tG, h tH) func(tA) tD {
+	return func(a tA) tD {
+		return h(g(f(a)))
+	}
+}
+
+// Pipe4(f, g, h, i)(x) == i(h(g(f(x))))
+func Pipe4[
+	tA, tB, tC, tD, tE any,
+	tF ~func(tA) tB,
+	tG ~func(tB) tC,
+	tH ~func(tC) tD,
+	tI ~func(tD) tE,
+](f tF, g tG, h tH, i tI) func(tA) tE {
+	return func(a tA) tE {
+		return i(h(g(f(a))))
+	}
+}
+
+// Pipe5(f, g, h, i, j)(x) == j(i(h(g(f(x)))))
+func Pipe5[
+	tA, tB, tC, tD, tE, tF any,
+	tG ~func(tA) tB,
+	tH ~func(tB) tC,
+	tI ~func(tC) tD,
+	tJ ~func(tD) tE,
+	tK ~func(tE) tF,
+](f tG, g tH, h tI, i tJ, j tK) func(tA) tF {
+	return func(a tA) tF {
+		return j(i(h(g(f(a)))))
+	}
+}
+
+// Pipe6(f, g, h, i, j, k)(x) == k(j(i(h(g(f(x))))))
+func Pipe6[
+	tA, tB, tC, tD, tE, tF, tG any,
+	tH ~func(tA) tB,
+	tI ~func(tB) tC,
+	tJ ~func(tC) tD,
+	tK ~func(tD) tE,
+	tL ~func(tE) tF,
+	tM ~func(tF) tG,
+](f tH, g tI, h tJ, i tK, j tL, k tM) func(tA) tG {
+	return func(a tA) tG {
+		return k(j(i(h(g(f(a))))))
+	}
+}
+
+// Pipe7(f, g, h, i, j, k, l)(x) == l(k(j(i(h(g(f(x)))))))
+func Pipe7[
+	tA, tB, tC, tD, tE, tF, tG, tH any,
+	tI ~func(tA) tB,
+	tJ ~func(tB) tC,
+	tK ~func(tC) tD,
+	tL ~func(tD) tE,
+	tM ~func(tE) tF,
+	tN ~func(tF) tG,
+	tO ~func(tG) tH,
+](f tI, g tJ, h tK, i tL, j tM, k tN, l tO) func(tA) tH {
+	return func(a tA) tH {
+		return l(k(j(i(h(g(f(a)))))))
+	}
+}
+
+// Pipe8(f, g, h, i, j, k, l, m)(x) == m(l(k(j(i(h(g(f(x))))))))
+func Pipe8[
+	tA, tB, tC, tD, tE, tF, tG, tH, tI any,
+	tJ ~func(tA) tB,
+	tK ~func(tB) tC,
+	tL ~func(tC) tD,
+	tM ~func(tD) tE,
+	tN ~func(tE) tF,
+	tO ~func(tF) tG,
+	tP ~func(tG) tH,
+	tQ ~func(tH) tI,
+](f tJ, g tK, h tL, i tM, j tN, k tO, l tP, m tQ) func(tA) tI {
+	return func(a tA) tI {
+		return m(l(k(j(i(h(g(f(a))))))))
+	}
+}
+
+// See Memoize()
+func Memoize2[
+	tA, tB comparable,
+	tC any,
+	tF ~func(tA, tB) tC,
+](f tF, cache Cache[Tuple[tA, tB], tC]) func(tA, tB) tC {
+	memo := Memoize(func(key Tuple[tA, tB]) tC {
+		return f(key.A, key.B)
+	}, cache)
+	return func(a tA, b tB) tC {
+		return memo(Tuple[tA, tB]{a, b})
+	}
+}
+
+// See Memoize()
+func Memoize3[
+	tA, tB, tC comparable,
+	tD any,
+	tF ~func(tA, tB, tC) tD,
+](f tF, cache Cache[Tuple3[tA, tB, tC], tD]) func(tA, tB, tC) tD {
+	memo := Memoize(func(key Tuple3[tA, tB, tC]) tD {
+		return f(key.A, key.B, key.C)
+	}, cache)
+	return func(a tA, b tB, c tC) tD {
+		return memo(Tuple3[tA, tB, tC]{a, b, c})
+	}
+}
+
+// See Memoize()
+func Memoize4[
+	tA, tB, tC, tD comparable,
+	tE any,
+	tF ~func(tA, tB, tC, tD) tE,
+](f tF, cache Cache[Tuple4[tA, tB, tC, tD], tE]) func(tA, tB, tC, tD) tE {
+	memo := Memoize(func(key Tuple4[tA, tB, tC, tD]) tE {
+		return f(key.A, key.B, key.C, key.D)
+	}, cache)
+	return func(a tA, b tB, c tC, d tD) tE {
+		return memo(Tuple4[tA, tB, tC, tD]{a, b, c, d})
+	}
+}
+
+// See Memoize()
+func Memoize5[
+	tA, tB, tC, tD, tE comparable,
+	tF any,
+	tG ~func(tA, tB, tC, tD, tE) tF,
+](f tG, cache Cache[Tuple5[tA, tB, tC, tD, tE], tF]) func(tA, tB, tC, tD, tE) tF {
+	memo := Memoize(func(key Tuple5[tA, tB, tC, tD, tE]) tF {
+		return f(key.A, key.B, key.C, key.D, key.E)
+	}, cache)
+	return func(a tA, b tB, c tC, d tD, e tE) tF {
+		return memo(Tuple5[tA, tB, tC, tD, tE]{a, b, c, d, e})
+	}
+}
+
+// See Memoize()
+func Memoize6[
+	tA, tB, tC, tD, tE, tF comparable,
+	tG any,
+	tH ~func(tA, tB, tC, tD, tE, tF) tG,
+](f tH, cache Cache[Tuple6[tA, tB, tC, tD, tE, tF], tG]) func(tA, tB, tC, tD, tE, tF) tG {
+	memo := Memoize(func(key Tuple6[tA, tB, tC, tD, tE, tF]) tG {
+		return f(key.A, key.B, key.C, key.D, key.E, key.F)
+	}, cache)
+	return func(a tA, b tB, c tC, d tD, e tE, g tF) tG {
+		return memo(Tuple6[tA, tB, tC, tD, tE, tF]{a, b, c, d, e, g})
+	}
+}
+
+// See Memoize()
+func Memoize7[
+	tA, tB, tC, tD, tE, tF, tG comparable,
+	tH any,
+	tI ~func(tA, tB, tC, tD, tE, tF, tG) tH,
+](f tI, cache Cache[Tuple7[tA, tB, tC, tD, tE, tF, tG], tH]) func(tA, tB, tC, tD, tE, tF, tG) tH {
+	memo := Memoize(func(key Tuple7[tA, tB, tC, tD, tE, tF, tG]) tH {
+		return f(key.A, key.B, key.C, key.D, key.E, key.F, key.G)
+	}, cache)
+	return func(a tA, b tB, c tC, d tD, e tE, g tF, h tG) tH {
+		return memo(Tuple7[tA, tB, tC, tD, tE, tF, tG]{a, b, c, d, e, g, h})
+	}
+}
+
+// See Memoize()
+func Memoize8[
+	tA, tB, tC, tD, tE, tF, tG, tH comparable,
+	tI any,
+	tJ ~func(tA, tB, tC, tD, tE, tF, tG, tH) tI,
+](f tJ, cache Cache[Tuple8[tA, tB, tC, tD, tE, tF, tG, tH], tI]) func(tA, tB, tC, tD, tE, tF, tG, tH) tI {
+	memo := Memoize(func(key Tuple8[tA, tB, tC, tD, tE, tF, tG, tH]) tI {
+		return f(key.A, key.B, key.C, key.D, key.E, key.F, key.G, key.H)
+	}, cache)
+	return func(a tA, b tB, c tC, d tD, e tE, g tF, h tG, i tH) tI {
+		return memo(Tuple8[tA, tB, tC, tD, tE, tF, tG, tH]{a, b, c, d, e, g, h, i})
+	}
+}
+
+func NoError[tA any](a tA, err error) tA {
+	MustNil(err)
+	return a
+}
+
+func NoError2[tA, tB any](a tA, b tB, err error) (tA, tB) {
+	MustNil(err)
+	return a, b
+}
+
+func NoError3[tA, tB, tC any](a tA, b tB, c tC, err error) (tA, tB, tC) {
+	MustNil(err)
+	return a, b, c
+}
+
+func NoError4[tA, tB, tC, tD any](a tA, b tB, c tC, d tD, err error) (tA, tB, tC, tD) {
+	MustNil(err)
+	return a, b, c, d
+}
+
+func NoError5[tA, tB, tC, tD, tE any](a tA, b tB, c tC, d tD, e tE, err error) (tA, tB, tC, tD, tE) {
+	MustNil(err)
+	return a, b, c, d, e
+}
+
+func NoError6[tA, tB, tC, tD, tE, tF any](a tA, b tB, c tC, d tD, e tE, g tF, err error) (tA, tB, tC, tD, tE, tF) {
+	MustNil(err)
+	return a, b, c, d, e, g
+}
+
+func NoError7[tA, tB, tC, tD, tE, tF, tG any](a tA, b tB, c tC, d tD, e tE, g tF, h tG, err error) (tA, tB, tC, tD, tE, tF, tG) {
+	MustNil(err)
+	return a, b, c, d, e, g, h
+}
+
+func NoError8[tA, tB, tC, tD, tE, tF, tG, tH any](a tA, b tB, c tC, d tD, e tE, g tF, h tG, i tH, err error) (tA, tB, tC, tD, tE, tF, tG, tH) {
+	MustNil(err)
+	return a, b, c, d, e, g, h, i
+}
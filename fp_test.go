@@ -1,10 +1,13 @@
 package fp
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
+	"testing/quick"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/stretchr/testify/require"
@@ -99,6 +102,10 @@ func TestPredicatesAndOrdering(t *testing.T) {
 	require.True(t, Any(IsZero[int], 12, 13, 0, 14))
 }
 
+func TestClamp(t *testing.T) {
+	require.Equal(t, []int{0, 0, 5, 10, 10}, Map(Clamp(0, 10), -5, 0, 5, 10, 15))
+}
+
 func TestConditions(t *testing.T) {
 	require.True(t, Cond(true, false)(false))
 	require.True(t, CondZ(true)(true))
@@ -152,6 +159,103 @@ func TestMinMax(t *testing.T) {
 		res := Minimum(1, 2, 3, 4, 5)
 		require.Equal(t, 1, res)
 	}
+
+	{
+		min, i := MinimumIndex(3, 2, 1, 4)
+		require.Equal(t, 1, min)
+		require.Equal(t, 2, i)
+
+		max, i := MaximumIndex(3, 2, 5, 4)
+		require.Equal(t, 5, max)
+		require.Equal(t, 2, i)
+
+		require.Equal(t, -1, ArgMin[int]())
+		require.Equal(t, 3, ArgMax(1, 2, 3, 4))
+	}
+}
+
+func TestMinMaxBy(t *testing.T) {
+	type item struct {
+		Name string
+		W    int
+	}
+	w := func(e item) int { return e.W }
+	a := []item{{"a", 2}, {"b", 1}, {"c", 3}, {"d", 1}, {"e", 3}}
+
+	{
+		min, i := MinBy(w, TieFirst, a...)
+		require.Equal(t, "b", min.Name)
+		require.Equal(t, 1, i)
+
+		min, i = MinBy(w, TieLast, a...)
+		require.Equal(t, "d", min.Name)
+		require.Equal(t, 3, i)
+	}
+
+	{
+		max, i := MaxBy(w, TieFirst, a...)
+		require.Equal(t, "c", max.Name)
+		require.Equal(t, 2, i)
+
+		max, i = MaxBy(w, TieLast, a...)
+		require.Equal(t, "e", max.Name)
+		require.Equal(t, 4, i)
+	}
+
+	{
+		min, max, iMin, iMax := MinMaxBy(w, TieFirst, a...)
+		require.Equal(t, "b", min.Name)
+		require.Equal(t, "c", max.Name)
+		require.Equal(t, 1, iMin)
+		require.Equal(t, 2, iMax)
+	}
+
+	{
+		_, i := MinBy(w, TieFirst)
+		require.Equal(t, -1, i)
+
+		_, _, iMin, iMax := MinMaxWith(Compare[int], TieLast)
+		require.Equal(t, -1, iMin)
+		require.Equal(t, -1, iMax)
+	}
+}
+
+func TestMinMaxIndexProperty(t *testing.T) {
+	// Brute force reference: first or last index holding the extreme value
+	bruteForce := func(a []int8, less func(a, b int8) bool, tie Tie) int {
+		indx := -1
+		for i, e := range a {
+			if indx == -1 || less(e, a[indx]) ||
+				tie == TieLast && !less(a[indx], e) {
+				indx = i
+			}
+		}
+		return indx
+	}
+	lt := func(a, b int8) bool { return a < b }
+	gt := func(a, b int8) bool { return a > b }
+
+	prop := func(a []int8) bool {
+		ok := true
+		for _, tie := range []Tie{TieFirst, TieLast} {
+			_, iMin := MinWith(Compare[int8], tie, a...)
+			_, iMax := MaxWith(Compare[int8], tie, a...)
+			_, _, iMin2, iMax2 := MinMaxWith(Compare[int8], tie, a...)
+
+			ok = ok &&
+				iMin == bruteForce(a, lt, tie) &&
+				iMax == bruteForce(a, gt, tie) &&
+				iMin2 == iMin && iMax2 == iMax
+		}
+
+		_, iMin := MinimumIndex(a...)
+		_, iMax := MaximumIndex(a...)
+		return ok &&
+			iMin == bruteForce(a, lt, TieFirst) &&
+			iMax == bruteForce(a, gt, TieFirst)
+	}
+
+	require.NoError(t, quick.Check(prop, nil))
 }
 
 func TestNoDuplicates(t *testing.T) {
@@ -166,6 +270,34 @@ func TestNoDuplicates(t *testing.T) {
 	require.False(t, NoDups(1, 2, 1, 3, 4, 5))
 }
 
+func TestDistinct(t *testing.T) {
+	require.Equal(t, []int{3, 1, 2}, Distinct(3, 1, 3, 2, 1))
+	require.Equal(t, []int{}, Distinct[int]())
+
+	require.Equal(t, []string{"a", "bb"},
+		DistinctBy(func(s string) int { return len(s) }, "a", "bb", "c", "dd"))
+
+	require.Equal(t, []int{1, 2, 1, 3}, DistinctAdjacent(1, 1, 2, 2, 2, 1, 3))
+	require.Equal(t, []int{1, 2, 3}, DistinctSorted(1, 1, 2, 3, 3))
+
+	require.True(t, NoDupsSorted(1, 2, 3))
+	require.False(t, NoDupsSorted(1, 2, 2, 3))
+}
+
+func TestDuplicates(t *testing.T) {
+	require.Empty(t, Duplicates(1, 2, 3))
+
+	require.Equal(t, []DupGroup[int, int]{
+		{Key: 2, Values: []int{2, 2}, Indexes: []int{1, 4}},
+		{Key: 1, Values: []int{1, 1, 1}, Indexes: []int{2, 3, 5}},
+	}, Duplicates(3, 2, 1, 1, 2, 1))
+
+	res := DuplicatesBy(IsEven[int], 1, 2, 3)
+	require.Equal(t, []DupGroup[bool, int]{
+		{Key: false, Values: []int{1, 3}, Indexes: []int{0, 2}},
+	}, res)
+}
+
 func TestSumProd(t *testing.T) {
 	{
 		res := Sum(1, 2, 3, 4, 5)
@@ -268,6 +400,109 @@ func TestMust(t *testing.T) {
 	}()
 }
 
+func TestMustDescribed(t *testing.T) {
+	func() {
+		defer func() {
+			me := recover().(MustError)
+			require.Equal(t, `failure for value "-1": must be positive`, me.Error())
+			require.Nil(t, me.Pred)
+		}()
+
+		Must(Gt(0), "must be positive", -1)
+	}()
+
+	func() {
+		defer func() {
+			me := recover().(MustError)
+			require.Equal(t, `failure for value "1": odd`, me.Error())
+			require.Equal(t, "odd", me.Pred.String())
+			require.False(t, me.Pred.Test(me.Val))
+			require.True(t, me.Pred.Test(2))
+			require.False(t, me.Pred.Test("2"))
+		}()
+
+		MustBe(Describe("odd", IsEven[int]), 1)
+	}()
+
+	func() {
+		defer func() {
+			me := recover().(MustError)
+			require.Equal(t, `failure for value "2": duplicate value "a"; index: 2`,
+				me.Error())
+			require.Equal(t, "== -1", me.Pred.String())
+		}()
+
+		Enum("a", "b", "a")
+	}()
+
+	func() {
+		defer func() {
+			me := recover().(MustError)
+			require.Equal(t, `failure for value "-1": positive`, me.Error())
+			require.Equal(t, "positive", me.Pred.String())
+		}()
+
+		Must(positive(Gt(0)), "", -1)
+	}()
+
+	func() {
+		defer func() {
+			me := recover().(MustError)
+			require.Equal(t, `failure for value "<nil>" at position 1: nil value is not allowed`,
+				me.Error())
+			require.Equal(t, "non-zero", me.Pred.String())
+		}()
+
+		MustNonNil(1, nil)
+	}()
+}
+
+type positive func(int) bool
+
+func (p positive) Test(v int) bool { return p(v) }
+func (positive) String() string    { return "positive" }
+
+func TestCheck(t *testing.T) {
+	errNotFound := errors.New("not found")
+	wrapped := fmt.Errorf("open config: %w", errNotFound)
+
+	{
+		me := recoverMust(func() { NoErrorf(0, wrapped, "loading %s", "app.yaml") })
+		require.Equal(t,
+			`failure for value "open config: not found": loading app.yaml`,
+			me.Error())
+		require.ErrorIs(t, me, errNotFound)
+	}
+
+	{
+		me := recoverMust(func() { Check(wrapped, "loading config") })
+		require.ErrorIs(t, me, errNotFound)
+		require.Equal(t, "loading config", me.Msg)
+
+		me = recoverMust(func() { Checkf(wrapped, "loading %d", 1) })
+		require.Equal(t, "loading 1", me.Msg)
+	}
+
+	{
+		ctx := WithRequestID(context.Background(), "req-42")
+		me := recoverMust(func() { NoErrorCtx(ctx, 0, wrapped, "loading config") })
+		require.Equal(t, "req-42", me.RequestID)
+		require.Equal(t,
+			`failure for value "open config: not found": loading config [request req-42]`,
+			me.Error())
+
+		me = recoverMust(func() { CheckCtx(context.Background(), wrapped, "x") })
+		require.Empty(t, me.RequestID)
+	}
+
+	{
+		require.Equal(t, 1, NoErrorMsg(1, nil, "unused"))
+		require.Equal(t, 1, NoErrorf(1, nil, "unused"))
+		Check(nil, "unused")
+		CheckCtx(context.Background(), nil, "unused")
+	}
+}
+
 func TestCast(t *testing.T) {
 	var _ int64 = CastNum[int64](float64(100))
 
@@ -299,3 +534,176 @@ func TestRand(t *testing.T) {
 func TestStrConcat(t *testing.T) {
 	fmt.Println(Map(Add("ololo"), "1", "2", "3"))
 }
+
+func TestMaps(t *testing.T) {
+	type ages map[string]int
+	m := ages{"alice": 25, "bob": 30, "carol": 35}
+	adult := func(_ string, v int) bool { return v >= 30 }
+
+	{
+		res := MFilter(adult, m)
+		require.Equal(t, ages{"bob": 30, "carol": 35}, res)
+	}
+
+	{
+		require.ElementsMatch(t, []string{"alice", "bob", "carol"}, MKeys(m))
+		require.ElementsMatch(t, []int{25, 30, 35}, MValues(m))
+		require.Equal(t, []string{"alice", "bob", "carol"}, MSortedKeys(m))
+	}
+
+	{
+		res := MInvert(m)
+		require.Equal(t, map[int]string{25: "alice", 30: "bob", 35: "carol"}, res)
+	}
+
+	{
+		res := MMerge(func(_ string, old, new int) int {
+			return old + new
+		}, m, ages{"bob": 1, "dave": 40})
+		require.Equal(t, ages{"alice": 25, "bob": 31, "carol": 35, "dave": 40}, res)
+		require.Equal(t, 30, m["bob"])
+	}
+
+	{
+		require.Equal(t, ages{"alice": 25}, MPick(m, "alice", "zed"))
+		require.Equal(t, ages{"carol": 35}, MOmit(m, "alice", "bob"))
+	}
+
+	{
+		yes, no := MPartition(adult, m)
+		require.Equal(t, ages{"bob": 30, "carol": 35}, yes)
+		require.Equal(t, ages{"alice": 25}, no)
+	}
+
+	{
+		k, v, ok := MFind(func(_ string, v int) bool { return v > 30 }, m)
+		require.True(t, ok)
+		require.Equal(t, "carol", k)
+		require.Equal(t, 35, v)
+
+		_, _, ok = MFind(adult, ages{})
+		require.False(t, ok)
+	}
+
+	{
+		require.True(t, MAny(adult, m))
+		require.False(t, MAll(adult, m))
+		require.True(t, MAll(adult, ages{}))
+		require.True(t, MIsEmpty(ages{}))
+	}
+}
+
+func TestMMapMKCollisions(t *testing.T) {
+	m := map[string]int{"a": 1, "B": 2, "A": 3}
+	lower := func(k string, v int) (string, int) {
+		return strings.ToLower(k), v
+	}
+
+	{
+		res := MMapMKWith(lower, func(_ string, old, new int) int {
+			return old + new
+		}, m)
+		require.Equal(t, map[string]int{"a": 4, "b": 2}, res)
+	}
+
+	{
+		// "A" < "B" < "a"
+		res := MMapMKWithSorted(lower, KeepFirst[string, int], m)
+		require.Equal(t, map[string]int{"a": 3, "b": 2}, res)
+
+		res = MMapMKWithSorted(lower, KeepLast[string, int], m)
+		require.Equal(t, map[string]int{"a": 1, "b": 2}, res)
+
+		desc := Comparator[string](Compare[string]).Reversed()
+		res = MMapMKWithBy(desc, lower, KeepFirst[string, int], m)
+		require.Equal(t, map[string]int{"a": 1, "b": 2}, res)
+	}
+
+	{
+		_, err := MMapMKE(lower, m)
+		var me MustError
+		require.ErrorAs(t, err, &me)
+		c := me.Val.(KeyCollision[string, string])
+		require.Equal(t, "a", c.Key)
+		require.ElementsMatch(t, []string{"a", "A"}, c.Src[:])
+
+		res, err := MMapMKE(lower, map[string]int{"a": 1, "B": 2})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{"a": 1, "b": 2}, res)
+	}
+
+	require.Panics(t, func() {
+		MMapMKStrict(lower, m)
+	})
+}
+
+func TestMReduceSorted(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "b": 2, "d": 4}
+	concat := func(acc string, k string, v int) string {
+		return acc + k + strconv.Itoa(v)
+	}
+
+	for i := 0; i < 10; i++ {
+		require.Equal(t, "a1b2c3d4", MReduceSorted(concat, "", m))
+		require.Equal(t, "d4c3b2a1",
+			MReduceBy(Comparator[string](Compare[string]).Reversed(), concat, "", m))
+		require.Equal(t, []int{1, 2, 3, 4}, MMapSorted(func(v int) int { return v }, m))
+		require.Equal(t, []string{"a", "b", "c", "d"},
+			MMapKSorted(func(k string, _ int) string { return k }, m))
+	}
+}
+
+func TestCompose(t *testing.T) {
+	strlen := func(s string) int { return len(s) }
+
+	{
+		long := Compose(Gt(3), strlen)
+		require.Equal(t, []string{"four", "fives"},
+			Filter(long, "one", "two", "four", "fives"))
+		require.True(t, All(Compose(Lt(10), strlen), "a", "bb"))
+	}
+
+	{
+		f := Pipe3(strlen, Add(1), strconv.Itoa)
+		require.Equal(t, "4", f("abc"))
+		require.Equal(t, "4", Compose3(strconv.Itoa, Add(1), strlen)("abc"))
+		require.Equal(t, 10, Pipe5(Add(1), Add(1), Add(1), Add(1), Add(1))(5))
+	}
+
+	{
+		sub := func(a, b int) int { return a - b }
+		require.Equal(t, 1, Flip(sub)(2, 3))
+		require.Equal(t, -1, Partial2(sub, 2)(3))
+		require.Equal(t, 6, Partial3(Apply3(Sum[int]), 1)(2, 3))
+		require.Equal(t, 5, Uncurry2(Lazy2(sub))(8, 3))
+		require.Equal(t, 10, Uncurry4(Lazy4(Apply4(Sum[int])))(1, 2, 3, 4))
+	}
+
+	{
+		require.Equal(t, []int{1, 2, 3}, Map(Identity[int], 1, 2, 3))
+		require.Equal(t, []string{"x", "x"}, Map(Const[string, int]("x"), 1, 2))
+	}
+}
+
+func TestGeneratedArities(t *testing.T) {
+	{
+		res := Zip3([]int{1, 2}, []string{"a", "b", "c"}, []bool{true, false})
+		require.Equal(t, []Tuple3[int, string, bool]{
+			{1, "a", true},
+			{2, "b", false},
+		}, res)
+	}
+
+	{
+		sum := Uncurry8(Lazy8(Apply8(Sum[int])))
+		require.Equal(t, 36, sum(1, 2, 3, 4, 5, 6, 7, 8))
+		require.Equal(t, 35, Partial8(Apply8(Sum[int]), 0)(2, 3, 4, 5, 6, 7, 8))
+	}
+
+	{
+		f := Pipe8(Add(1), Add(1), Add(1), Add(1), Add(1), Add(1), Add(1), strconv.Itoa)
+		require.Equal(t, "7", f(0))
+		_, _, _, d := NoError4(1, 2, 3, 4, nil)
+		require.Equal(t, 4, d)
+	}
+}
@@ -0,0 +1,250 @@
+package fp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+//////////
+/// Futures
+
+var (
+	// Wraps the value a future's function panicked with
+	ErrFuturePanic = errors.New("future panicked")
+	// Returned by AnyOf() and FirstOf() when there are no futures to wait for
+	ErrNoFutures = errors.New("no futures")
+)
+
+// Result of an asynchronous computation. It settles exactly once and may be
+// awaited any number of times from any goroutine.
+type Future[tA any] struct {
+	once sync.Once
+	done chan struct{}
+	res  Result[tA]
+}
+
+func newFuture[tA any]() *Future[tA] {
+	return &Future[tA]{done: make(chan struct{})}
+}
+
+// Settles fut with r unless it's already settled
+func (fut *Future[tA]) settle(r Result[tA]) {
+	fut.once.Do(func() {
+		fut.res = r
+		close(fut.done)
+	})
+}
+
+// Blocks until fut is settled
+func (fut *Future[tA]) wait() (tA, error) {
+	<-fut.done
+	return fut.res.Get()
+}
+
+// Runs f in a new goroutine. A panic in f fails the future with an error
+// wrapping ErrFuturePanic instead of crashing the program.
+func Go[tA any, tF ~func() (tA, error)](f tF) *Future[tA] {
+	fut := newFuture[tA]()
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				fut.settle(Fail[tA](panicError(r)))
+			}
+		}()
+		fut.settle(ResultOf(f()))
+	}()
+	return fut
+}
+
+func panicError(r any) error {
+	if err, ok := r.(error); ok {
+		return fmt.Errorf("%w: %w", ErrFuturePanic, err)
+	}
+	return fmt.Errorf("%w: %v", ErrFuturePanic, r)
+}
+
+// Returns a future along with the function settling it; only the first call
+// of complete takes effect
+func NewPromise[tA any]() (fut *Future[tA], complete func(tA, error)) {
+	fut = newFuture[tA]()
+	return fut, func(v tA, err error) {
+		fut.settle(ResultOf(v, err))
+	}
+}
+
+// Waits for fut unless res settles first, ok is false in such a case.
+// Combinators use it, so that their goroutines don't outlive the result.
+func waitUnless[tA, tB any](
+	fut *Future[tA],
+	res *Future[tB],
+) (r Result[tA], ok bool) {
+	select {
+	case <-fut.done:
+		return fut.res, true
+	case <-res.done:
+		return r, false
+	}
+}
+
+// Returns future already settled with r
+func FromResult[tA any](r Result[tA]) *Future[tA] {
+	fut := newFuture[tA]()
+	fut.settle(r)
+	return fut
+}
+
+func Resolved[tA any](v tA) *Future[tA] {
+	return FromResult(Ok(v))
+}
+
+func Rejected[tA any](err error) *Future[tA] {
+	return FromResult(Fail[tA](err))
+}
+
+// Closed once fut is settled
+func (fut *Future[tA]) Done() <-chan struct{} {
+	return fut.done
+}
+
+// Waits for fut to settle; returns ctx.Err() if ctx is done first
+func (fut *Future[tA]) Await(ctx context.Context) (tA, error) {
+	select {
+	case <-fut.done:
+		return fut.res.Get()
+	case <-ctx.Done():
+		return Zero[tA](), ctx.Err()
+	}
+}
+
+// Same as Await(), but packs the outcome into Result
+func (fut *Future[tA]) Result(ctx context.Context) Result[tA] {
+	return ResultOf(fut.Await(ctx))
+}
+
+// Returns the outcome without blocking; ok is false if fut isn't settled yet
+func (fut *Future[tA]) Poll() (r Result[tA], ok bool) {
+	select {
+	case <-fut.done:
+		return fut.res, true
+	default:
+		return r, false
+	}
+}
+
+// Handles failure of fut with h; successful values are passed through
+func (fut *Future[tA]) Recover(h func(error) (tA, error)) *Future[tA] {
+	return Go(func() (tA, error) {
+		v, err := fut.wait()
+		if err != nil {
+			return h(err)
+		}
+		return v, nil
+	})
+}
+
+// Returns future of f applied to the value of fut; failures are passed
+// through and f isn't called
+func MapFuture[
+	tA, tB any,
+	tF ~func(tA) tB,
+](f tF, fut *Future[tA]) *Future[tB] {
+	return Go(func() (tB, error) {
+		v, err := fut.wait()
+		if err != nil {
+			return Zero[tB](), err
+		}
+		return f(v), nil
+	})
+}
+
+// Chains future returned by f after fut
+func FlatMapFuture[
+	tA, tB any,
+	tF ~func(tA) *Future[tB],
+](f tF, fut *Future[tA]) *Future[tB] {
+	return Go(func() (tB, error) {
+		v, err := fut.wait()
+		if err != nil {
+			return Zero[tB](), err
+		}
+		return f(v).wait()
+	})
+}
+
+// Succeeds with values of all of futs in their order; fails as soon as any
+// of them fails
+func AllOf[tA any](futs ...*Future[tA]) *Future[[]tA] {
+	res := newFuture[[]tA]()
+	vals := make([]tA, len(futs))
+	var wg sync.WaitGroup
+	for i, fut := range futs {
+		wg.Add(1)
+		go func(i int, fut *Future[tA]) {
+			defer wg.Done()
+			r, ok := waitUnless(fut, res)
+			switch {
+			case !ok:
+			case r.Err != nil:
+				res.settle(Fail[[]tA](r.Err))
+			default:
+				vals[i] = r.Val
+			}
+		}(i, fut)
+	}
+	go func() {
+		wg.Wait()
+		res.settle(Ok(vals))
+	}()
+	return res
+}
+
+// Succeeds with the first successful value of futs; fails with all of their
+// errors joined if every one of them fails
+func AnyOf[tA any](futs ...*Future[tA]) *Future[tA] {
+	if len(futs) == 0 {
+		return Rejected[tA](ErrNoFutures)
+	}
+
+	res := newFuture[tA]()
+	errs := make([]error, len(futs))
+	var wg sync.WaitGroup
+	for i, fut := range futs {
+		wg.Add(1)
+		go func(i int, fut *Future[tA]) {
+			defer wg.Done()
+			r, ok := waitUnless(fut, res)
+			switch {
+			case !ok:
+			case r.Err == nil:
+				res.settle(r)
+			default:
+				errs[i] = r.Err
+			}
+		}(i, fut)
+	}
+	go func() {
+		wg.Wait()
+		res.settle(Fail[tA](errors.Join(errs...)))
+	}()
+	return res
+}
+
+// Settles the same way as the first settled of futs, be it success or
+// failure
+func FirstOf[tA any](futs ...*Future[tA]) *Future[tA] {
+	if len(futs) == 0 {
+		return Rejected[tA](ErrNoFutures)
+	}
+
+	res := newFuture[tA]()
+	for _, fut := range futs {
+		go func(fut *Future[tA]) {
+			if r, ok := waitUnless(fut, res); ok {
+				res.settle(r)
+			}
+		}(fut)
+	}
+	return res
+}
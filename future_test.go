@@ -0,0 +1,142 @@
+package fp
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/loorke/fp/internal/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuture(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	{
+		fut := Go(func() (int, error) { return 42, nil })
+		v, err := fut.Await(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 42, v)
+
+		// Settled futures may be awaited again
+		require.Equal(t, Ok(42), fut.Result(ctx))
+	}
+
+	{
+		fut := Go(func() (int, error) { panic(errBoom) })
+		_, err := fut.Await(ctx)
+		require.ErrorIs(t, err, ErrFuturePanic)
+		require.ErrorIs(t, err, errBoom)
+	}
+
+	{
+		fut, complete := NewPromise[string]()
+		_, ok := fut.Poll()
+		require.False(t, ok)
+
+		cctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+		defer cancel()
+		_, err := fut.Await(cctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		complete("a", nil)
+		complete("b", nil)
+		r, ok := fut.Poll()
+		require.True(t, ok)
+		require.Equal(t, Ok("a"), r)
+	}
+}
+
+func TestFutureCombinators(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	{
+		fut := MapFuture(strconv.Itoa, Resolved(42))
+		require.Equal(t, Ok("42"), fut.Result(ctx))
+
+		fut = MapFuture(func(int) string {
+			panic("unreachable")
+		}, Rejected[int](errBoom))
+		require.ErrorIs(t, fut.Result(ctx).Err, errBoom)
+	}
+
+	{
+		fut := FlatMapFuture(func(v int) *Future[int] {
+			return Go(func() (int, error) { return v * 2, nil })
+		}, Resolved(21))
+		require.Equal(t, Ok(42), fut.Result(ctx))
+	}
+
+	{
+		fut := Rejected[int](errBoom).Recover(func(err error) (int, error) {
+			require.ErrorIs(t, err, errBoom)
+			return -1, nil
+		})
+		require.Equal(t, Ok(-1), fut.Result(ctx))
+
+		fut = Resolved(1).Recover(func(error) (int, error) {
+			panic("unreachable")
+		})
+		require.Equal(t, Ok(1), fut.Result(ctx))
+	}
+}
+
+func TestAllAnyFirstOf(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	// Settles after d
+	after := func(d time.Duration, v int, err error) *Future[int] {
+		return Go(func() (int, error) {
+			time.Sleep(d)
+			return v, err
+		})
+	}
+
+	{
+		fut := AllOf(
+			after(20*time.Millisecond, 1, nil),
+			Resolved(2),
+			after(10*time.Millisecond, 3, nil))
+		require.Equal(t, Ok([]int{1, 2, 3}), fut.Result(ctx))
+
+		require.Equal(t, Ok([]int{}), AllOf[int]().Result(ctx))
+	}
+
+	{
+		leaktest.Check(t, func() {
+			never, _ := NewPromise[int]()
+			fut := AllOf(never, Rejected[int](errBoom))
+			require.ErrorIs(t, fut.Result(ctx).Err, errBoom)
+		})
+	}
+
+	{
+		leaktest.Check(t, func() {
+			never, _ := NewPromise[int]()
+			fut := AnyOf(Rejected[int](errBoom), never, after(time.Millisecond, 2, nil))
+			require.Equal(t, Ok(2), fut.Result(ctx))
+		})
+
+		errOther := errors.New("other")
+		_, err := AnyOf(Rejected[int](errBoom), Rejected[int](errOther)).Await(ctx)
+		require.ErrorIs(t, err, errBoom)
+		require.ErrorIs(t, err, errOther)
+
+		require.ErrorIs(t, AnyOf[int]().Result(ctx).Err, ErrNoFutures)
+	}
+
+	{
+		leaktest.Check(t, func() {
+			never, _ := NewPromise[int]()
+			fut := FirstOf(never, after(time.Millisecond, 0, errBoom))
+			require.ErrorIs(t, fut.Result(ctx).Err, errBoom)
+		})
+
+		require.ErrorIs(t, FirstOf[int]().Result(ctx).Err, ErrNoFutures)
+	}
+}
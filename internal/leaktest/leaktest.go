@@ -0,0 +1,28 @@
+// Package leaktest checks tests for leaked goroutines
+package leaktest
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Fails t if goroutines started by f outlive it. Polls by hand, since
+// require.Eventually runs its own goroutines.
+func Check(t testing.TB, f func()) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	f()
+	for deadline := time.Now().Add(time.Second); ; {
+		n := runtime.NumGoroutine()
+		if n <= before {
+			return
+		}
+		if time.Now().After(deadline) {
+			require.FailNow(t, "goroutine leak", "%d goroutines left", n-before)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,108 @@
+package fp
+
+import "sync"
+
+//////////
+/// Lazy values
+
+// Thunk evaluated at most once, on the first Get(). Safe for concurrent use;
+// if evaluation panics, every Get() panics with the same value.
+type Lazy[tA any] struct {
+	once     sync.Once
+	f        func() tA
+	v        tA
+	panicked any
+}
+
+func NewLazy[tA any, tF ~func() tA](f tF) *Lazy[tA] {
+	return &Lazy[tA]{f: f}
+}
+
+// Returns already evaluated value
+func Eager[tA any](v tA) *Lazy[tA] {
+	l := &Lazy[tA]{v: v}
+	l.once.Do(func() {})
+	return l
+}
+
+func (l *Lazy[tA]) Get() tA {
+	l.once.Do(func() {
+		defer func() {
+			l.panicked = recover()
+			l.f = nil
+		}()
+		l.v = l.f()
+	})
+	if l.panicked != nil {
+		panic(l.panicked)
+	}
+	return l.v
+}
+
+// Returns lazy value of f(l.Get()); neither is evaluated until needed
+func MapLazy[
+	tA, tB any,
+	tF ~func(tA) tB,
+](f tF, l *Lazy[tA]) *Lazy[tB] {
+	return NewLazy(func() tB {
+		return f(l.Get())
+	})
+}
+
+// Evaluates all of ls
+func Force[tA any](ls ...*Lazy[tA]) []tA {
+	return Map((*Lazy[tA]).Get, ls...)
+}
+
+// Like Cond(), but evaluates only the chosen branch
+func CondLazy[tA any](left, right *Lazy[tA]) func(ok bool) tA {
+	return func(ok bool) tA {
+		return Cond(left, right)(ok).Get()
+	}
+}
+
+// Like Cond(), but calls only the chosen branch
+func CondF[
+	tA any,
+	tF ~func() tA,
+](left, right tF) func(ok bool) tA {
+	return func(ok bool) tA {
+		return Cond(left, right)(ok)()
+	}
+}
+
+// Chain of conditional branches, only the first taken one is evaluated:
+//
+//	size := If(n < 10, small).ElseIf(n < 100, medium).Else(large)
+//
+// Branches share the type of the first one, func literals fit any of them.
+type IfChain[
+	tA any,
+	tF ~func() tA,
+] struct {
+	done bool
+	v    tA
+}
+
+func If[tA any, tF ~func() tA](ok bool, then tF) IfChain[tA, tF] {
+	return IfChain[tA, tF]{}.ElseIf(ok, then)
+}
+
+func (c IfChain[tA, tF]) ElseIf(ok bool, then tF) IfChain[tA, tF] {
+	if c.done || !ok {
+		return c
+	}
+	return IfChain[tA, tF]{done: true, v: then()}
+}
+
+func (c IfChain[tA, tF]) Else(f tF) tA {
+	if c.done {
+		return c.v
+	}
+	return f()
+}
+
+// Returns zero value if no branch was taken
+func (c IfChain[tA, tF]) ElseZ() tA {
+	return c.v
+}
@@ -0,0 +1,81 @@
+package fp
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazy(t *testing.T) {
+	var calls atomic.Int32
+	l := NewLazy(func() int {
+		calls.Add(1)
+		return 42
+	})
+	require.Equal(t, int32(0), calls.Load())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.Equal(t, 42, l.Get())
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), calls.Load())
+
+	{
+		s := MapLazy(Add(1), l)
+		require.Equal(t, 43, s.Get())
+		require.Equal(t, []int{42, 43, 1}, Force(l, s, Eager(1)))
+		require.Equal(t, int32(1), calls.Load())
+	}
+
+	{
+		p := NewLazy(func() int { panic("boom") })
+		require.PanicsWithValue(t, "boom", func() { p.Get() })
+		require.PanicsWithValue(t, "boom", func() { p.Get() })
+	}
+}
+
+func TestCondLazy(t *testing.T) {
+	boom := func() string { panic("must not be evaluated") }
+
+	require.Equal(t, "ok", CondF(boom, func() string { return "ok" })(true))
+	require.Equal(t, "ok", CondLazy(Eager("ok"), NewLazy(boom))(false))
+}
+
+func TestIfChain(t *testing.T) {
+	var evaluated []string
+	branch := func(name string) func() string {
+		return func() string {
+			evaluated = append(evaluated, name)
+			return name
+		}
+	}
+
+	size := func(n int) string {
+		return If(n < 10, branch("small")).
+			ElseIf(n < 100, branch("medium")).
+			Else(branch("large"))
+	}
+
+	require.Equal(t, "small", size(1))
+	require.Equal(t, "medium", size(50))
+	require.Equal(t, "large", size(500))
+	require.Equal(t, []string{"small", "medium", "large"}, evaluated)
+
+	require.Equal(t, "", If(false, branch("x")).ElseZ())
+
+	{
+		type thunk func() string
+		var small, large thunk = branch("small"), branch("large")
+		require.Equal(t, "large",
+			If(false, small).ElseIf(false, small).Else(large))
+		require.Equal(t, "medium",
+			If(false, small).ElseIf(true, branch("medium")).Else(large))
+	}
+}
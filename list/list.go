@@ -0,0 +1,179 @@
+/*
+Package list provides persistent singly-linked lists in the spirit of Haskell
+lists. Lists are immutable: every operation returns a new list sharing as much
+structure with its operands as possible, so they're safe to share among
+goroutines without locking.
+
+	l := list.New(2, 3)
+	m := list.Cons(1, l) // [1, 2, 3], l is its tail
+*/
+package list
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/loorke/fp"
+)
+
+type node[tA any] struct {
+	head tA
+	tail *node[tA]
+	len  int
+}
+
+// Persistent list; the zero value is an empty list
+type List[tA any] struct {
+	n *node[tA]
+}
+
+// Returns list of a
+func New[tA any](a ...tA) List[tA] {
+	var l List[tA]
+	for i := len(a) - 1; i >= 0; i-- {
+		l = Cons(a[i], l)
+	}
+	return l
+}
+
+// Prepends v to l in O(1)
+func Cons[tA any](v tA, l List[tA]) List[tA] {
+	return List[tA]{&node[tA]{head: v, tail: l.n, len: l.Len() + 1}}
+}
+
+func (l List[tA]) IsEmpty() bool {
+	return l.n == nil
+}
+
+// O(1)
+func (l List[tA]) Len() int {
+	if l.n == nil {
+		return 0
+	}
+	return l.n.len
+}
+
+// Returns the first element; panics with MustError if l is empty
+func (l List[tA]) Head() tA {
+	fp.Must(fp.Not(List[tA].IsEmpty), "head of empty list", l)
+	return l.n.head
+}
+
+// Returns all but the first element; panics with MustError if l is empty
+func (l List[tA]) Tail() List[tA] {
+	fp.Must(fp.Not(List[tA].IsEmpty), "tail of empty list", l)
+	return List[tA]{l.n.tail}
+}
+
+// Splits l into head and tail; ok is false if l is empty
+func (l List[tA]) Uncons() (head tA, tail List[tA], ok bool) {
+	if l.n == nil {
+		return head, tail, false
+	}
+	return l.n.head, List[tA]{l.n.tail}, true
+}
+
+// Returns the first n elements
+func (l List[tA]) Take(n int) List[tA] {
+	if n >= l.Len() {
+		return l
+	}
+	return New(l.take(n)...)
+}
+
+// Returns all but the first n elements; shares them with l
+func (l List[tA]) Drop(n int) List[tA] {
+	for ; n > 0 && l.n != nil; n-- {
+		l.n = l.n.tail
+	}
+	return l
+}
+
+func (l List[tA]) take(n int) []tA {
+	res := make([]tA, 0, max(min(n, l.Len()), 0))
+	for nd := l.n; nd != nil && len(res) < n; nd = nd.tail {
+		res = append(res, nd.head)
+	}
+	return res
+}
+
+func (l List[tA]) Reverse() List[tA] {
+	var res List[tA]
+	for nd := l.n; nd != nil; nd = nd.tail {
+		res = Cons(nd.head, res)
+	}
+	return res
+}
+
+func (l List[tA]) ToSlice() []tA {
+	return l.take(l.Len())
+}
+
+// Formats l as "[1, 2, 3]"
+func (l List[tA]) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for nd := l.n; nd != nil; nd = nd.tail {
+		if nd != l.n {
+			b.WriteString(", ")
+		}
+		fmt.Fprint(&b, nd.head)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+func Map[
+	tA, tB any,
+	tF ~func(tA) tB,
+](f tF, l List[tA]) List[tB] {
+	return New(fp.Map(f, l.ToSlice()...)...)
+}
+
+// Keeps elements satisfying p; the longest suffix of l they all satisfy is
+// shared with it
+func Filter[
+	tA any,
+	tF ~func(tA) bool,
+](p tF, l List[tA]) List[tA] {
+	var kept []tA
+	// Start of the current run of satisfying elements
+	run := l.n
+	for nd := l.n; nd != nil; nd = nd.tail {
+		if !p(nd.head) {
+			for r := run; r != nd; r = r.tail {
+				kept = append(kept, r.head)
+			}
+			run = nd.tail
+		}
+	}
+
+	res := List[tA]{run}
+	for i := len(kept) - 1; i >= 0; i-- {
+		res = Cons(kept[i], res)
+	}
+	return res
+}
+
+// Left fold, see fp.Reduce()
+func Reduce[
+	tA, tB any,
+	tF ~func(tB, tA) tB,
+](f tF, z tB, l List[tA]) tB {
+	acc := z
+	for nd := l.n; nd != nil; nd = nd.tail {
+		acc = f(acc, nd.head)
+	}
+	return acc
+}
+
+// Right fold: FoldRight(f, z, [a, b]) == f(a, f(b, z)). Doesn't recurse, so
+// long lists don't exhaust the stack.
+func FoldRight[
+	tA, tB any,
+	tF ~func(tA, tB) tB,
+](f tF, z tB, l List[tA]) tB {
+	return Reduce(func(acc tB, v tA) tB {
+		return f(v, acc)
+	}, z, l.Reverse())
+}
@@ -0,0 +1,104 @@
+package list
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/loorke/fp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	l := New(1, 2, 3)
+	require.Equal(t, "[1, 2, 3]", l.String())
+	require.Equal(t, 3, l.Len())
+	require.Equal(t, []int{1, 2, 3}, l.ToSlice())
+
+	{
+		m := Cons(0, l)
+		require.Equal(t, "[0, 1, 2, 3]", m.String())
+		require.Equal(t, 0, m.Head())
+		// Tail is shared, not copied
+		require.Same(t, l.n, m.Tail().n)
+		require.Equal(t, "[1, 2, 3]", l.String())
+	}
+
+	{
+		h, tl, ok := l.Uncons()
+		require.True(t, ok)
+		require.Equal(t, 1, h)
+		require.Equal(t, []int{2, 3}, tl.ToSlice())
+
+		_, _, ok = List[int]{}.Uncons()
+		require.False(t, ok)
+	}
+
+	{
+		var e List[int]
+		require.True(t, e.IsEmpty())
+		require.Equal(t, "[]", e.String())
+		require.Empty(t, e.ToSlice())
+		require.Panics(t, func() { e.Head() })
+		require.Panics(t, func() { e.Tail() })
+	}
+}
+
+func TestTakeDropReverse(t *testing.T) {
+	l := New(1, 2, 3, 4)
+	require.Equal(t, []int{1, 2}, l.Take(2).ToSlice())
+	require.Equal(t, l, l.Take(10))
+	require.True(t, l.Take(-1).IsEmpty())
+
+	require.Equal(t, []int{3, 4}, l.Drop(2).ToSlice())
+	require.Same(t, l.n.tail.tail, l.Drop(2).n)
+	require.True(t, l.Drop(10).IsEmpty())
+	require.Equal(t, l, l.Drop(-1))
+
+	require.Equal(t, []int{4, 3, 2, 1}, l.Reverse().ToSlice())
+	require.Equal(t, []int{1, 2, 3, 4}, l.ToSlice())
+}
+
+func TestMapFilterFold(t *testing.T) {
+	l := New(1, 2, 3, 4, 6)
+
+	require.Equal(t, `[1, 2, 3, 4, 6]`, Map(strconv.Itoa, l).String())
+	require.Equal(t, 16, Reduce(fp.Apply2(fp.Sum[int]), 0, l))
+	require.Equal(t, "12346", FoldRight(func(v int, acc string) string {
+		return strconv.Itoa(v) + acc
+	}, "", l))
+
+	{
+		even := Filter(fp.IsEven[int], l)
+		require.Equal(t, []int{2, 4, 6}, even.ToSlice())
+		// Satisfying suffix is shared
+		require.Same(t, l.Drop(3).n, even.Drop(1).n)
+
+		require.Same(t, l.n, Filter(fp.Const[bool, int](true), l).n)
+		require.True(t, Filter(fp.Const[bool, int](false), l).IsEmpty())
+	}
+
+	{
+		// Deep lists don't exhaust the stack
+		long := New(make([]int, 1_000_000)...)
+		require.Equal(t, 1_000_000, FoldRight(func(_, acc int) int {
+			return acc + 1
+		}, 0, long))
+	}
+}
+
+func TestConcurrentSharing(t *testing.T) {
+	base := New(1, 2, 3)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l := Cons(i, base)
+			require.Equal(t, 4, l.Len())
+			require.Equal(t, 6, Reduce(fp.Apply2(fp.Sum[int]), 0, l.Tail()))
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, "[1, 2, 3]", base.String())
+}
@@ -0,0 +1,97 @@
+package fp
+
+//////////
+/// Pattern matching
+
+// Builder mapping values onto results by the first matching case:
+//
+//	describe := NewMatch[int, string]().
+//		CaseEq(0, Const[string, int]("zero")).
+//		Case(Gt(100), Const[string, int]("large")).
+//		Default(strconv.Itoa)
+//
+//	describe.Eval(0) // "zero"
+//
+// Cases are evaluated in order of declaration; use CaseType() for type
+// switches. A Match must not be modified once shared between goroutines.
+type Match[tA, tB any] struct {
+	cases      []matchCase[tA, tB]
+	def        func(tA) tB
+	exhaustive bool
+}
+
+type matchCase[tA, tB any] struct {
+	guard func(tA) bool
+	f     func(tA) tB
+}
+
+func NewMatch[tA, tB any]() *Match[tA, tB] {
+	return &Match[tA, tB]{}
+}
+
+// Adds case taken if guard holds, e.g. Gt(10) or Includes(1, 2, 3)
+func (m *Match[tA, tB]) Case(guard func(tA) bool, f func(tA) tB) *Match[tA, tB] {
+	m.cases = append(m.cases, matchCase[tA, tB]{guard, f})
+	return m
+}
+
+// Adds case taken if the value equals v. Values are compared as interfaces,
+// so comparing non-comparable values panics.
+func (m *Match[tA, tB]) CaseEq(v tA, f func(tA) tB) *Match[tA, tB] {
+	return m.Case(func(a tA) bool {
+		return any(a) == any(v)
+	}, f)
+}
+
+// Adds case taken if the value holds type tX
+func CaseType[tX, tA, tB any](
+	m *Match[tA, tB],
+	f func(tX) tB,
+) *Match[tA, tB] {
+	return m.Case(func(a tA) bool {
+		_, ok := any(a).(tX)
+		return ok
+	}, func(a tA) tB {
+		return f(any(a).(tX))
+	})
+}
+
+// Sets the fallback used if no case matches
+func (m *Match[tA, tB]) Default(f func(tA) tB) *Match[tA, tB] {
+	m.def = f
+	return m
+}
+
+// Makes Eval() panic with MustError if no case matches and there's no
+// default
+func (m *Match[tA, tB]) Exhaustive() *Match[tA, tB] {
+	m.exhaustive = true
+	return m
+}
+
+// Returns result of the first matching case or the default. If neither
+// applies, returns zero value, or panics if the match is exhaustive.
+func (m *Match[tA, tB]) Eval(v tA) tB {
+	res, err := m.EvalE(v)
+	if err != nil && m.exhaustive {
+		panic(err)
+	}
+	return res
+}
+
+// Like Eval(), but returns MustError if neither a case nor the default
+// applies
+func (m *Match[tA, tB]) EvalE(v tA) (tB, error) {
+	c, ok := Find(func(c matchCase[tA, tB]) bool {
+		return c.guard(v)
+	}, m.cases...)
+
+	switch {
+	case ok:
+		return c.f(v), nil
+	case m.def != nil:
+		return m.def(v), nil
+	default:
+		return Zero[tB](), MustError{Msg: "no case matched", Val: v}.capture()
+	}
+}
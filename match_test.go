@@ -0,0 +1,49 @@
+package fp
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	m := NewMatch[int, string]().
+		CaseEq(0, Const[string, int]("zero")).
+		Case(Includes(1, 2, 3), Const[string, int]("few")).
+		Case(Gt(100), Const[string, int]("many")).
+		Default(strconv.Itoa)
+
+	require.Equal(t,
+		[]string{"zero", "few", "50", "many"},
+		Map(m.Eval, 0, 2, 50, 101))
+
+	{
+		first := NewMatch[int, string]().
+			Case(Gt(0), Const[string, int]("positive")).
+			Case(Gt(10), Const[string, int]("large"))
+		require.Equal(t, "positive", first.Eval(20))
+		require.Equal(t, "", first.Eval(-1))
+
+		_, err := first.EvalE(-1)
+		var me MustError
+		require.ErrorAs(t, err, &me)
+		require.Equal(t, -1, me.Val)
+	}
+}
+
+func TestMatchType(t *testing.T) {
+	m := NewMatch[any, string]()
+	CaseType(m, func(s string) string { return "string " + s })
+	CaseType(m, func(err error) string { return "error " + err.Error() })
+	CaseType(m, func(n int) string { return "int " + strconv.Itoa(n) })
+	m.Exhaustive()
+
+	require.Equal(t, "string a", m.Eval("a"))
+	require.Equal(t, "int 1", m.Eval(1))
+	require.Equal(t, "error boom", m.Eval(fmt.Errorf("boom")))
+
+	me := recoverMust(func() { m.Eval(1.5) })
+	require.Equal(t, `failure for value "1.5": no case matched`, me.Error())
+}
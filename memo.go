@@ -0,0 +1,209 @@
+package fp
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+//////////
+/// Memoization
+
+// Storage for memoized results. Implementations must be safe for concurrent
+// use.
+type Cache[tK comparable, tV any] interface {
+	Get(k tK) (v tV, ok bool)
+	Set(k tK, v tV)
+}
+
+// Returns f caching its results in c; unbounded cache is used if c is nil.
+// Concurrent calls with the same argument share a single evaluation of f,
+// a panic in f is propagated to all of them and nothing is cached.
+// Multi-argument functions are memoized by Memoize2, Memoize3 and so on
+// using Tuple keys.
+func Memoize[
+	tA comparable,
+	tB any,
+	tF ~func(tA) tB,
+](f tF, c Cache[tA, tB]) func(tA) tB {
+	if c == nil {
+		c = NewUnboundedCache[tA, tB]()
+	}
+
+	var mu sync.Mutex
+	inflight := map[tA]*memoCall[tB]{}
+
+	return func(a tA) tB {
+		if v, ok := c.Get(a); ok {
+			return v
+		}
+
+		mu.Lock()
+		if call, ok := inflight[a]; ok {
+			mu.Unlock()
+			return call.wait()
+		}
+		// The result could have been stored while we were waiting for mu
+		if v, ok := c.Get(a); ok {
+			mu.Unlock()
+			return v
+		}
+		call := &memoCall[tB]{done: make(chan struct{})}
+		inflight[a] = call
+		mu.Unlock()
+
+		defer func() {
+			if call.panicked = recover(); call.panicked == nil {
+				c.Set(a, call.val)
+			}
+
+			mu.Lock()
+			delete(inflight, a)
+			mu.Unlock()
+			close(call.done)
+
+			if call.panicked != nil {
+				panic(call.panicked)
+			}
+		}()
+
+		call.val = f(a)
+		return call.val
+	}
+}
+
+// Evaluation of a memoized function shared by concurrent callers
+type memoCall[tA any] struct {
+	done     chan struct{}
+	val      tA
+	panicked any
+}
+
+func (c *memoCall[tA]) wait() tA {
+	<-c.done
+	if c.panicked != nil {
+		panic(c.panicked)
+	}
+	return c.val
+}
+
+// Cache that never evicts
+type UnboundedCache[tK comparable, tV any] struct {
+	mu sync.RWMutex
+	m  map[tK]tV
+}
+
+func NewUnboundedCache[tK comparable, tV any]() *UnboundedCache[tK, tV] {
+	return &UnboundedCache[tK, tV]{m: map[tK]tV{}}
+}
+
+func (c *UnboundedCache[tK, tV]) Get(k tK) (tV, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	v, ok := c.m[k]
+	return v, ok
+}
+
+func (c *UnboundedCache[tK, tV]) Set(k tK, v tV) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[k] = v
+}
+
+// Cache evicting the least recently used entry when its capacity is exceeded
+type LRUCache[tK comparable, tV any] struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // of Tuple[tK, tV], most recent first
+	m        map[tK]*list.Element
+}
+
+// Panics with MustError if capacity isn't positive
+func NewLRUCache[tK comparable, tV any](capacity int) *LRUCache[tK, tV] {
+	Must(Gt(0), "LRU cache capacity must be positive", capacity)
+	return &LRUCache[tK, tV]{
+		capacity: capacity,
+		order:    list.New(),
+		m:        make(map[tK]*list.Element, capacity),
+	}
+}
+
+func (c *LRUCache[tK, tV]) Get(k tK) (tV, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.m[k]
+	if !ok {
+		return Zero[tV](), false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(Tuple[tK, tV]).B, true
+}
+
+func (c *LRUCache[tK, tV]) Set(k tK, v tV) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.m[k]; ok {
+		e.Value = Tuple[tK, tV]{k, v}
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.m[k] = c.order.PushFront(Tuple[tK, tV]{k, v})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Remove(c.order.Back()).(Tuple[tK, tV])
+		delete(c.m, oldest.A)
+	}
+}
+
+func (c *LRUCache[tK, tV]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Cache whose entries expire after a fixed time to live. Expired entries are
+// dropped lazily on access.
+type TTLCache[tK comparable, tV any] struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	clock Clock
+	m     map[tK]Tuple[tV, time.Time] // value and its expiration time
+}
+
+// SystemClock is used if clock is nil
+func NewTTLCache[tK comparable, tV any](
+	ttl time.Duration,
+	clock Clock,
+) *TTLCache[tK, tV] {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	return &TTLCache[tK, tV]{
+		ttl:   ttl,
+		clock: clock,
+		m:     map[tK]Tuple[tV, time.Time]{},
+	}
+}
+
+func (c *TTLCache[tK, tV]) Get(k tK) (tV, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.m[k]
+	if !ok {
+		return Zero[tV](), false
+	}
+	if !c.clock.Now().Before(e.B) {
+		delete(c.m, k)
+		return Zero[tV](), false
+	}
+	return e.A, true
+}
+
+func (c *TTLCache[tK, tV]) Set(k tK, v tV) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[k] = Tuple[tV, time.Time]{v, c.clock.Now().Add(c.ttl)}
+}
@@ -0,0 +1,126 @@
+package fp
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoize(t *testing.T) {
+	var calls int
+	square := Memoize(func(x int) int {
+		calls++
+		return x * x
+	}, nil)
+
+	require.Equal(t, 4, square(2))
+	require.Equal(t, 4, square(2))
+	require.Equal(t, 9, square(3))
+	require.Equal(t, 2, calls)
+
+	{
+		calls = 0
+		add := Memoize2(func(a, b int) int {
+			calls++
+			return a + b
+		}, NewUnboundedCache[Tuple[int, int], int]())
+
+		require.Equal(t, 3, add(1, 2))
+		require.Equal(t, 3, add(1, 2))
+		require.Equal(t, 3, add(2, 1))
+		require.Equal(t, 2, calls)
+	}
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	slow := Memoize(func(x int) int {
+		calls.Add(1)
+		<-release
+		return x + 1
+	}, nil)
+
+	var wg sync.WaitGroup
+	res := make([]int, 10)
+	for i := range res {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res[i] = slow(1)
+		}(i)
+	}
+
+	// Let callers pile up on the in-flight evaluation
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), calls.Load())
+	require.True(t, All(Eq(2), res...))
+}
+
+func TestMemoizePanic(t *testing.T) {
+	var calls int
+	f := Memoize(func(x int) int {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		return x
+	}, nil)
+
+	require.PanicsWithValue(t, "boom", func() { f(1) })
+	require.Equal(t, 1, f(1))
+	require.Equal(t, 2, calls)
+}
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache[string, int](2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	c.Set("c", 3)
+	require.Equal(t, 2, c.Len())
+
+	_, ok = c.Get("b")
+	require.False(t, ok)
+
+	v, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+
+	c.Set("a", 10)
+	v, _ = c.Get("a")
+	require.Equal(t, 10, v)
+
+	require.Panics(t, func() { NewLRUCache[string, int](0) })
+}
+
+func TestTTLCache(t *testing.T) {
+	clock := newFakeClock()
+	c := NewTTLCache[string, int](time.Minute, clock)
+
+	var calls int
+	f := Memoize(func(k string) int {
+		calls++
+		return len(k)
+	}, c)
+
+	require.Equal(t, 3, f("abc"))
+	clock.Advance(59 * time.Second)
+	require.Equal(t, 3, f("abc"))
+	require.Equal(t, 1, calls)
+
+	clock.Advance(time.Second)
+	_, ok := c.Get("abc")
+	require.False(t, ok)
+	require.Equal(t, 3, f("abc"))
+	require.Equal(t, 2, calls)
+}
@@ -0,0 +1,314 @@
+package fp
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+//////////
+/// Nested maps
+
+var (
+	ErrPathNotFound = errors.New("path segment not found")
+	ErrPathNotMap   = errors.New("path segment is not a map")
+	ErrPathType     = errors.New("unexpected value type")
+	ErrPathEmpty    = errors.New("empty path")
+	ErrMergeClash   = errors.New("unresolved merge conflict")
+)
+
+// Failure to follow path in a map[string]any tree. Pos is the index of the
+// failed segment within Path.
+type PathError struct {
+	Path []string
+	Pos  int
+	Err  error
+}
+
+func (e PathError) Error() string {
+	return fmt.Sprintf("segment %q of %q: %s",
+		e.Segment(), strings.Join(e.Path, "."), e.Err)
+}
+
+func (e PathError) Unwrap() error {
+	return e.Err
+}
+
+// Returns the failed segment; empty if the path is empty
+func (e PathError) Segment() string {
+	if e.Pos < 0 || e.Pos >= len(e.Path) {
+		return ""
+	}
+	return e.Path[e.Pos]
+}
+
+// Converts e into MustError reporting the failed segment and its position;
+// the cause stays reachable with errors.Is()
+func (e PathError) must() MustError {
+	return MustError{
+		Msg:   fmt.Sprintf("%s in %q", e.Err, strings.Join(e.Path, ".")),
+		Val:   e.Segment(),
+		Pos:   Ref(e.Pos),
+		Cause: e.Err,
+	}.capture()
+}
+
+func mustPath(err error) {
+	var pe PathError
+	if errors.As(err, &pe) {
+		panic(pe.must())
+	}
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Returns value found by path, converted to tA
+func GetIn[tA any](m map[string]any, path ...string) (tA, error) {
+	var cur any = m
+	for i, k := range path {
+		node, ok := cur.(map[string]any)
+		if !ok {
+			return Zero[tA](), PathError{path, i, ErrPathNotMap}
+		}
+		if cur, ok = node[k]; !ok {
+			return Zero[tA](), PathError{path, i, ErrPathNotFound}
+		}
+	}
+
+	v, ok := cur.(tA)
+	if !ok {
+		return Zero[tA](), PathError{path, len(path) - 1, ErrPathType}
+	}
+	return v, nil
+}
+
+// Like GetIn(), but panics with MustError
+func MustGetIn[tA any](m map[string]any, path ...string) tA {
+	v, err := GetIn[tA](m, path...)
+	mustPath(err)
+	return v
+}
+
+// Returns a copy of m with v stored by path. Missing intermediate maps are
+// created; only maps along the path are copied, the rest is shared with m.
+func SetIn(m map[string]any, v any, path ...string) (map[string]any, error) {
+	return UpdateIn(m, func(any, bool) any {
+		return v
+	}, path...)
+}
+
+// Like SetIn(), but panics with MustError
+func MustSetIn(m map[string]any, v any, path ...string) map[string]any {
+	res, err := SetIn(m, v, path...)
+	mustPath(err)
+	return res
+}
+
+// Returns a copy of m with the value by path replaced with f(old, ok), where
+// ok reports whether the value existed. See SetIn() for sharing rules.
+func UpdateIn[
+	tF ~func(old any, ok bool) any,
+](m map[string]any, f tF, path ...string) (map[string]any, error) {
+	if len(path) == 0 {
+		return nil, PathError{path, 0, ErrPathEmpty}
+	}
+	return updateIn(m, path, 0, func(node map[string]any, k string) {
+		old, ok := node[k]
+		node[k] = f(old, ok)
+	})
+}
+
+// Like UpdateIn(), but panics with MustError
+func MustUpdateIn[
+	tF ~func(old any, ok bool) any,
+](m map[string]any, f tF, path ...string) map[string]any {
+	res, err := UpdateIn(m, f, path...)
+	mustPath(err)
+	return res
+}
+
+// Returns a copy of m without the value by path; fails if it doesn't exist.
+// See SetIn() for sharing rules.
+func DeleteIn(m map[string]any, path ...string) (map[string]any, error) {
+	if len(path) == 0 {
+		return nil, PathError{path, 0, ErrPathEmpty}
+	}
+
+	var missing bool
+	res, err := updateIn(m, path, 0, func(node map[string]any, k string) {
+		_, ok := node[k]
+		missing = !ok
+		delete(node, k)
+	})
+	if err == nil && missing {
+		return nil, PathError{path, len(path) - 1, ErrPathNotFound}
+	}
+	return res, err
+}
+
+// Like DeleteIn(), but panics with MustError
+func MustDeleteIn(m map[string]any, path ...string) map[string]any {
+	res, err := DeleteIn(m, path...)
+	mustPath(err)
+	return res
+}
+
+// Copies m and applies leaf to the copy of the last map on the path
+func updateIn(
+	m map[string]any,
+	path []string,
+	i int,
+	leaf func(node map[string]any, k string),
+) (map[string]any, error) {
+
+	node := make(map[string]any, len(m)+1)
+	maps.Copy(node, m)
+
+	k := path[i]
+	if i == len(path)-1 {
+		leaf(node, k)
+		return node, nil
+	}
+
+	var next map[string]any
+	if v, ok := node[k]; ok {
+		if next, ok = v.(map[string]any); !ok {
+			return nil, PathError{path, i, ErrPathNotMap}
+		}
+	}
+
+	next, err := updateIn(next, path, i+1, leaf)
+	if err != nil {
+		return nil, err
+	}
+	node[k] = next
+	return node, nil
+}
+
+// Resolves a conflict of two non-map values met at path by DeepMerge();
+// ok is false if the rule doesn't apply.
+type MergeRule func(path []string, a, b any) (v any, ok bool)
+
+// Rule applying f if both conflicting values are of type tA
+func MergeType[
+	tA any,
+	tF ~func(path []string, a, b tA) tA,
+](f tF) MergeRule {
+	return func(path []string, a, b any) (any, bool) {
+		ta, ok := a.(tA)
+		if !ok {
+			return nil, false
+		}
+		tb, ok := b.(tA)
+		if !ok {
+			return nil, false
+		}
+		return f(path, ta, tb), true
+	}
+}
+
+// Rule concatenating slices of type []tA
+func MergeConcat[tA any]() MergeRule {
+	return MergeType(func(_ []string, a, b []tA) []tA {
+		return Concat(a, b)
+	})
+}
+
+// Rule keeping the left value
+func MergeKeepLeft(_ []string, a, _ any) (any, bool) {
+	return a, true
+}
+
+// Rule keeping the right value
+func MergeKeepRight(_ []string, _, b any) (any, bool) {
+	return b, true
+}
+
+// Recursively merges b into a copy of a. Nested maps are merged; other
+// conflicting values are resolved by the first applicable rule, the right
+// value wins if none applies.
+func DeepMerge(a, b map[string]any, rules ...MergeRule) map[string]any {
+	res, _ := deepMerge(a, b, nil,
+		append(rules[:len(rules):len(rules)], MergeKeepRight))
+	return res
+}
+
+// Like DeepMerge(), but fails with PathError if no rule applies to a conflict
+func DeepMergeE(
+	a, b map[string]any,
+	rules ...MergeRule,
+) (map[string]any, error) {
+	return deepMerge(a, b, nil, rules)
+}
+
+// Like DeepMergeE(), but panics with MustError
+func MustDeepMerge(a, b map[string]any, rules ...MergeRule) map[string]any {
+	res, err := DeepMergeE(a, b, rules...)
+	mustPath(err)
+	return res
+}
+
+func deepMerge(
+	a, b map[string]any,
+	path []string,
+	rules []MergeRule,
+) (map[string]any, error) {
+
+	res := make(map[string]any, len(a)+len(b))
+	maps.Copy(res, a)
+	for k, vb := range b {
+		va, ok := res[k]
+		if !ok {
+			res[k] = vb
+			continue
+		}
+
+		p := append(path[:len(path):len(path)], k)
+		ma, okA := va.(map[string]any)
+		mb, okB := vb.(map[string]any)
+		if okA && okB {
+			v, err := deepMerge(ma, mb, p, rules)
+			if err != nil {
+				return nil, err
+			}
+			res[k] = v
+			continue
+		}
+
+		var resolved bool
+		for _, r := range rules {
+			if v, ok := r(p, va, vb); ok {
+				res[k], resolved = v, true
+				break
+			}
+		}
+		if !resolved {
+			return nil, PathError{p, len(p) - 1, ErrMergeClash}
+		}
+	}
+	return res, nil
+}
+
+// Returns a copy of m with every non-map value replaced with f(path, v)
+func DeepMapValues[
+	tF ~func(path []string, v any) any,
+](f tF, m map[string]any) map[string]any {
+	return deepMapValues(f, m, nil)
+}
+
+func deepMapValues[
+	tF ~func(path []string, v any) any,
+](f tF, m map[string]any, path []string) map[string]any {
+	res := make(map[string]any, len(m))
+	for k, v := range m {
+		p := append(path[:len(path):len(path)], k)
+		if node, ok := v.(map[string]any); ok {
+			res[k] = deepMapValues(f, node, p)
+		} else {
+			res[k] = f(p, v)
+		}
+	}
+	return res
+}
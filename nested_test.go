@@ -0,0 +1,166 @@
+package fp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testTree() map[string]any {
+	return map[string]any{
+		"db": map[string]any{
+			"host": "localhost",
+			"port": 5432,
+		},
+		"debug": true,
+	}
+}
+
+func TestGetIn(t *testing.T) {
+	m := testTree()
+
+	{
+		port, err := GetIn[int](m, "db", "port")
+		require.NoError(t, err)
+		require.Equal(t, 5432, port)
+	}
+
+	{
+		_, err := GetIn[int](m, "db", "user")
+		var pe PathError
+		require.ErrorAs(t, err, &pe)
+		require.ErrorIs(t, err, ErrPathNotFound)
+		require.Equal(t, 1, pe.Pos)
+		require.Equal(t, "user", pe.Segment())
+		require.Equal(t,
+			`segment "user" of "db.user": path segment not found`,
+			err.Error())
+	}
+
+	{
+		_, err := GetIn[int](m, "debug", "x")
+		require.ErrorIs(t, err, ErrPathNotMap)
+
+		_, err = GetIn[string](m, "db", "port")
+		require.ErrorIs(t, err, ErrPathType)
+	}
+
+	func() {
+		defer func() {
+			rec := recover()
+			me, ok := rec.(MustError)
+			require.True(t, ok)
+			require.Equal(t, "port", me.Val)
+			require.Equal(t, 1, *me.Pos)
+			require.Equal(t,
+				`failure for value "port" at position 1: `+
+					`unexpected value type in "db.port"`,
+				me.Error())
+			require.ErrorIs(t, me, ErrPathType)
+		}()
+
+		MustGetIn[bool](m, "db", "port")
+	}()
+}
+
+func TestSetIn(t *testing.T) {
+	m := testTree()
+
+	{
+		res, err := SetIn(m, "root", "db", "user")
+		require.NoError(t, err)
+		require.Equal(t, "root", MustGetIn[string](res, "db", "user"))
+
+		_, err = GetIn[string](m, "db", "user")
+		require.ErrorIs(t, err, ErrPathNotFound)
+	}
+
+	{
+		res := MustSetIn(m, 1, "a", "b", "c")
+		require.Equal(t, 1, MustGetIn[int](res, "a", "b", "c"))
+	}
+
+	{
+		_, err := SetIn(m, 1, "debug", "level")
+		require.ErrorIs(t, err, ErrPathNotMap)
+
+		_, err = SetIn(m, 1)
+		require.ErrorIs(t, err, ErrPathEmpty)
+	}
+
+	{
+		res := MustUpdateIn(m, func(old any, ok bool) any {
+			require.True(t, ok)
+			return old.(int) + 1
+		}, "db", "port")
+		require.Equal(t, 5433, MustGetIn[int](res, "db", "port"))
+	}
+
+	{
+		res, err := DeleteIn(m, "db", "host")
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"port": 5432}, res["db"])
+		require.Equal(t, "localhost", MustGetIn[string](m, "db", "host"))
+
+		_, err = DeleteIn(m, "db", "user")
+		require.ErrorIs(t, err, ErrPathNotFound)
+
+		me := recoverMust(func() { MustDeleteIn(m, "nope") })
+		require.ErrorIs(t, me, ErrPathNotFound)
+	}
+}
+
+func TestDeepMerge(t *testing.T) {
+	a := map[string]any{
+		"db":   map[string]any{"host": "localhost", "port": 5432},
+		"tags": []string{"a"},
+	}
+	b := map[string]any{
+		"db":   map[string]any{"port": 6543, "user": "root"},
+		"tags": []string{"b"},
+	}
+
+	{
+		res := DeepMerge(a, b)
+		require.Equal(t, map[string]any{
+			"db":   map[string]any{"host": "localhost", "port": 6543, "user": "root"},
+			"tags": []string{"b"},
+		}, res)
+	}
+
+	{
+		res := DeepMerge(a, b,
+			MergeConcat[string](),
+			MergeType(func(_ []string, a, b int) int {
+				return Maximum(a, b)
+			}))
+		require.Equal(t, []string{"a", "b"}, res["tags"])
+		require.Equal(t, 6543, MustGetIn[int](res, "db", "port"))
+	}
+
+	{
+		_, err := DeepMergeE(a, b, MergeConcat[string]())
+		var pe PathError
+		require.ErrorAs(t, err, &pe)
+		require.True(t, errors.Is(err, ErrMergeClash))
+		require.Equal(t, []string{"db", "port"}, pe.Path)
+
+		me := recoverMust(func() {
+			MustDeepMerge(a, b, MergeConcat[string]())
+		})
+		require.ErrorIs(t, me, ErrMergeClash)
+		require.Equal(t, "port", me.Val)
+	}
+}
+
+func TestDeepMapValues(t *testing.T) {
+	res := DeepMapValues(func(path []string, v any) any {
+		return len(path)
+	}, testTree())
+
+	require.Equal(t, map[string]any{
+		"db":    map[string]any{"host": 2, "port": 2},
+		"debug": 1,
+	}, res)
+}
@@ -0,0 +1,134 @@
+package pmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+)
+
+// FNV-1a parameters
+const (
+	offset64 = 14695981039346656037
+	prime64  = 1099511628211
+)
+
+// Hashes any comparable value so that equal values have equal hashes. The
+// hash is FNV-1a of encodeKey(k); unlike hash/maphash it doesn't depend on a
+// random seed, so it's the same across runs unless k holds pointers.
+func hashOf[tA comparable](k tA) uint64 {
+	h := uint64(offset64)
+	// Encoding of interface keys is tagged with the dynamic type, so fast
+	// paths only match encodeKey() for concrete ones
+	if reflect.TypeOf((*tA)(nil)).Elem().Kind() == reflect.Interface {
+		return hashBytes(h, encodeKey(k))
+	}
+	// Fast paths for common keys, matching encodeKey()
+	switch k := any(k).(type) {
+	case string:
+		return hashString(h, k)
+	case int:
+		return hashUint(h, uint64(k))
+	case int64:
+		return hashUint(h, uint64(k))
+	case uint64:
+		return hashUint(h, k)
+	}
+	return hashBytes(h, encodeKey(k))
+}
+
+func hashBytes(h uint64, b []byte) uint64 {
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= prime64
+	}
+	return h
+}
+
+func hashUint(h, v uint64) uint64 {
+	for i := 0; i < 8; i++ {
+		h ^= v & 0xff
+		h *= prime64
+		v >>= 8
+	}
+	return h
+}
+
+func hashString(h uint64, s string) uint64 {
+	h = hashUint(h, uint64(len(s)))
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime64
+	}
+	return h
+}
+
+// Orders keys by their encoding; used for keys sharing the whole hash
+func compareKeys[tA comparable](a, b tA) int {
+	return bytes.Compare(encodeKey(a), encodeKey(b))
+}
+
+// Returns canonical encoding of k: equal keys are encoded equally
+func encodeKey[tA comparable](k tA) []byte {
+	return encode(nil, reflect.ValueOf(&k).Elem())
+}
+
+func appendUint(b []byte, v uint64) []byte {
+	return binary.LittleEndian.AppendUint64(b, v)
+}
+
+func appendString(b []byte, s string) []byte {
+	return append(appendUint(b, uint64(len(s))), s...)
+}
+
+func appendFloat(b []byte, f float64) []byte {
+	// +0 == -0, but their bits differ
+	if f == 0 {
+		f = 0
+	}
+	return appendUint(b, math.Float64bits(f))
+}
+
+func encode(b []byte, v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return appendUint(b, 1)
+		}
+		return appendUint(b, 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return appendUint(b, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return appendUint(b, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return appendFloat(b, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return appendFloat(appendFloat(b, real(c)), imag(c))
+	case reflect.String:
+		return appendString(b, v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		// Addresses differ between runs
+		return appendUint(b, uint64(v.Pointer()))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			b = encode(b, v.Index(i))
+		}
+		return b
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			b = encode(b, v.Field(i))
+		}
+		return b
+	case reflect.Interface:
+		if v.IsNil() {
+			return appendString(b, "")
+		}
+		e := v.Elem()
+		return encode(appendString(b, e.Type().String()), e)
+	}
+	// Not comparable, so == would have panicked as well
+	panic("pmap: unhashable type " + v.Type().String())
+}
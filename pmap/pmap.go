@@ -0,0 +1,438 @@
+/*
+Package pmap provides persistent hash maps based on hash array mapped tries.
+Maps are immutable: Assoc, Dissoc and friends return new versions in
+O(log32 n) time, sharing all but the changed path with the original, so
+keeping snapshots of large state is cheap:
+
+	m1 := pmap.FromMap(map[string]int{"a": 1})
+	m2 := m1.Assoc("b", 2) // m1 still has just "a"
+
+Iteration order is stable: it only depends on the set of keys, not on the
+order they were added in. It's also the same across runs, unless keys hold
+pointers or channels, which are hashed by address. Functions mirroring M*
+functions of package fp take the same arguments, so pipelines can switch
+from Go maps gradually.
+*/
+package pmap
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+
+	"github.com/loorke/fp"
+)
+
+const (
+	bitsPerLevel = 5
+	levelMask    = 1<<bitsPerLevel - 1
+)
+
+type entry[tA comparable, tB any] struct {
+	hash uint64
+	key  tA
+	val  tB
+}
+
+// Either a branch or, if it's run out of hash bits, a bucket of colliding
+// entries
+type node[tA comparable, tB any] struct {
+	bitmap uint32
+	// Slots ordered by the hash chunk; either kids[i] or ents[i] is set
+	slots []slot[tA, tB]
+	// Entries sharing the whole hash, ordered by compareKeys()
+	bucket []entry[tA, tB]
+}
+
+type slot[tA comparable, tB any] struct {
+	kid *node[tA, tB]
+	ent entry[tA, tB]
+}
+
+// Persistent map; the zero value is an empty map
+type PMap[tA comparable, tB any] struct {
+	root *node[tA, tB]
+	size int
+}
+
+func New[tA comparable, tB any]() PMap[tA, tB] {
+	return PMap[tA, tB]{}
+}
+
+func FromMap[
+	tM ~map[tA]tB,
+	tA comparable,
+	tB any,
+](m tM) PMap[tA, tB] {
+	var res PMap[tA, tB]
+	for k, v := range m {
+		res = res.Assoc(k, v)
+	}
+	return res
+}
+
+func (m PMap[tA, tB]) ToMap() map[tA]tB {
+	res := make(map[tA]tB, m.size)
+	m.Each(func(k tA, v tB) bool {
+		res[k] = v
+		return true
+	})
+	return res
+}
+
+func (m PMap[tA, tB]) Len() int {
+	return m.size
+}
+
+func (m PMap[tA, tB]) Get(k tA) (tB, bool) {
+	return m.get(hashOf(k), k)
+}
+
+func (m PMap[tA, tB]) get(h uint64, k tA) (tB, bool) {
+	n := m.root
+	for shift := uint(0); n != nil; shift += bitsPerLevel {
+		if n.bucket != nil {
+			for _, e := range n.bucket {
+				if e.key == k {
+					return e.val, true
+				}
+			}
+			break
+		}
+
+		bit, i := n.index(h, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		s := n.slots[i]
+		if s.kid == nil {
+			if s.ent.key == k {
+				return s.ent.val, true
+			}
+			break
+		}
+		n = s.kid
+	}
+	return fp.Zero[tB](), false
+}
+
+func (m PMap[tA, tB]) Contains(k tA) bool {
+	_, ok := m.Get(k)
+	return ok
+}
+
+// Returns bit of the hash chunk at shift and position of its slot
+func (n *node[tA, tB]) index(h uint64, shift uint) (bit uint32, i int) {
+	bit = 1 << ((h >> shift) & levelMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// Returns map with k set to v
+func (m PMap[tA, tB]) Assoc(k tA, v tB) PMap[tA, tB] {
+	root, added := assoc(m.root, 0, entry[tA, tB]{hashOf(k), k, v})
+	m.root = root
+	if added {
+		m.size++
+	}
+	return m
+}
+
+func assoc[tA comparable, tB any](
+	n *node[tA, tB],
+	shift uint,
+	e entry[tA, tB],
+) (res *node[tA, tB], added bool) {
+	if n == nil {
+		n = &node[tA, tB]{}
+	}
+
+	if n.bucket != nil {
+		bucket := fp.Concat(n.bucket)
+		for i := range bucket {
+			if bucket[i].key == e.key {
+				bucket[i] = e
+				return &node[tA, tB]{bucket: bucket}, false
+			}
+		}
+		i := len(bucket)
+		for i > 0 && compareKeys(e.key, bucket[i-1].key) < 0 {
+			i--
+		}
+		return &node[tA, tB]{bucket: slices.Insert(bucket, i, e)}, true
+	}
+
+	bit, i := n.index(e.hash, shift)
+	if n.bitmap&bit == 0 {
+		slots := make([]slot[tA, tB], 0, len(n.slots)+1)
+		slots = append(slots, n.slots[:i]...)
+		slots = append(slots, slot[tA, tB]{ent: e})
+		slots = append(slots, n.slots[i:]...)
+		return &node[tA, tB]{bitmap: n.bitmap | bit, slots: slots}, true
+	}
+
+	s := n.slots[i]
+	switch {
+	case s.kid != nil:
+		s.kid, added = assoc(s.kid, shift+bitsPerLevel, e)
+	case s.ent.key == e.key:
+		s.ent = e
+	default:
+		s = slot[tA, tB]{kid: pair(shift+bitsPerLevel, s.ent, e)}
+		added = true
+	}
+
+	slots := fp.Concat(n.slots)
+	slots[i] = s
+	return &node[tA, tB]{bitmap: n.bitmap, slots: slots}, added
+}
+
+// Returns node holding distinct keys a and b
+func pair[tA comparable, tB any](
+	shift uint,
+	a, b entry[tA, tB],
+) *node[tA, tB] {
+	if shift >= 64 {
+		if compareKeys(a.key, b.key) > 0 {
+			a, b = b, a
+		}
+		return &node[tA, tB]{bucket: []entry[tA, tB]{a, b}}
+	}
+
+	ia := uint32(a.hash>>shift) & levelMask
+	ib := uint32(b.hash>>shift) & levelMask
+	switch {
+	case ia == ib:
+		return &node[tA, tB]{
+			bitmap: 1 << ia,
+			slots:  []slot[tA, tB]{{kid: pair(shift+bitsPerLevel, a, b)}},
+		}
+	case ia > ib:
+		a, b = b, a
+	}
+	return &node[tA, tB]{
+		bitmap: 1<<ia | 1<<ib,
+		slots:  []slot[tA, tB]{{ent: a}, {ent: b}},
+	}
+}
+
+// Returns map without k
+func (m PMap[tA, tB]) Dissoc(k tA) PMap[tA, tB] {
+	root, removed := dissoc(m.root, 0, hashOf(k), k)
+	if !removed {
+		return m
+	}
+	return PMap[tA, tB]{root: root, size: m.size - 1}
+}
+
+// Returns n without k or nil if it's left empty
+func dissoc[tA comparable, tB any](
+	n *node[tA, tB],
+	shift uint,
+	h uint64,
+	k tA,
+) (res *node[tA, tB], removed bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	if n.bucket != nil {
+		for i, e := range n.bucket {
+			if e.key == k {
+				bucket := append(fp.Concat(n.bucket[:i]), n.bucket[i+1:]...)
+				return &node[tA, tB]{bucket: bucket}, true
+			}
+		}
+		return n, false
+	}
+
+	bit, i := n.index(h, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	s := n.slots[i]
+	if s.kid == nil {
+		if s.ent.key != k {
+			return n, false
+		}
+		return n.without(bit, i), true
+	}
+
+	kid, removed := dissoc(s.kid, shift+bitsPerLevel, h, k)
+	if !removed {
+		return n, false
+	}
+	if kid == nil {
+		return n.without(bit, i), true
+	}
+	if e, ok := kid.single(); ok {
+		// Pull lone entry up, so that the trie stays as shallow as possible
+		s = slot[tA, tB]{ent: e}
+	} else {
+		s = slot[tA, tB]{kid: kid}
+	}
+	slots := fp.Concat(n.slots)
+	slots[i] = s
+	return &node[tA, tB]{bitmap: n.bitmap, slots: slots}, true
+}
+
+// Returns n without i-th slot or nil if it's left empty
+func (n *node[tA, tB]) without(bit uint32, i int) *node[tA, tB] {
+	if len(n.slots) == 1 {
+		return nil
+	}
+	slots := append(fp.Concat(n.slots[:i]), n.slots[i+1:]...)
+	return &node[tA, tB]{bitmap: n.bitmap &^ bit, slots: slots}
+}
+
+// Returns the only entry of n if it has just one and no subnodes
+func (n *node[tA, tB]) single() (e entry[tA, tB], ok bool) {
+	switch {
+	case n.bucket != nil:
+		if len(n.bucket) == 1 {
+			return n.bucket[0], true
+		}
+	case len(n.slots) == 1 && n.slots[0].kid == nil:
+		return n.slots[0].ent, true
+	}
+	return e, false
+}
+
+// Returns map with k set to f(old value, whether it's present)
+func (m PMap[tA, tB]) Update(k tA, f func(old tB, ok bool) tB) PMap[tA, tB] {
+	return m.Assoc(k, f(m.Get(k)))
+}
+
+// Calls f for each entry in iteration order until it returns false
+func (m PMap[tA, tB]) Each(f func(k tA, v tB) bool) {
+	m.root.each(f)
+}
+
+func (n *node[tA, tB]) each(f func(k tA, v tB) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, e := range n.bucket {
+		if !f(e.key, e.val) {
+			return false
+		}
+	}
+	for _, s := range n.slots {
+		if s.kid != nil {
+			if !s.kid.each(f) {
+				return false
+			}
+		} else if !f(s.ent.key, s.ent.val) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns keys in iteration order
+func (m PMap[tA, tB]) Keys() []tA {
+	return MMapK(func(k tA, _ tB) tA {
+		return k
+	}, m)
+}
+
+// Returns values in iteration order
+func (m PMap[tA, tB]) Values() []tB {
+	return MMap(fp.Identity[tB], m)
+}
+
+// Formats m as "{a: 1, b: 2}" in iteration order
+func (m PMap[tA, tB]) String() string {
+	strs := MMapK(func(k tA, v tB) string {
+		return fmt.Sprintf("%v: %v", k, v)
+	}, m)
+	return "{" + strings.Join(strs, ", ") + "}"
+}
+
+// Merges ms left to right, resolving keys present in several of them with
+// conflict; see fp.MMerge()
+func Merge[
+	tF ~func(k tA, old, new tB) tB,
+	tA comparable,
+	tB any,
+](conflict tF, ms ...PMap[tA, tB]) PMap[tA, tB] {
+	var res PMap[tA, tB]
+	for _, m := range ms {
+		if res.size == 0 {
+			// Nothing to resolve, share m as a whole
+			res = m
+			continue
+		}
+		m.Each(func(k tA, v tB) bool {
+			if old, ok := res.Get(k); ok {
+				v = conflict(k, old, v)
+			}
+			res = res.Assoc(k, v)
+			return true
+		})
+	}
+	return res
+}
+
+// See fp.MReduce()
+func MReduce[
+	tF ~func(tC, tA, tB) tC,
+	tA comparable,
+	tB, tC any,
+](f tF, z tC, m PMap[tA, tB]) tC {
+	acc := z
+	m.Each(func(k tA, v tB) bool {
+		acc = f(acc, k, v)
+		return true
+	})
+	return acc
+}
+
+// See fp.MMapK()
+func MMapK[
+	tF ~func(tA, tB) tC,
+	tA comparable,
+	tB, tC any,
+](f tF, m PMap[tA, tB]) []tC {
+	return MReduce(func(acc []tC, k tA, v tB) []tC {
+		return append(acc, f(k, v))
+	}, make([]tC, 0, m.size), m)
+}
+
+// See fp.MMap()
+func MMap[
+	tF ~func(tB) tC,
+	tA comparable,
+	tB, tC any,
+](f tF, m PMap[tA, tB]) []tC {
+	return MMapK(func(_ tA, v tB) tC {
+		return f(v)
+	}, m)
+}
+
+// See fp.MMapM()
+func MMapM[
+	tF ~func(tB) tC,
+	tA comparable,
+	tB, tC any,
+](f tF, m PMap[tA, tB]) PMap[tA, tC] {
+	return MReduce(func(acc PMap[tA, tC], k tA, v tB) PMap[tA, tC] {
+		return acc.Assoc(k, f(v))
+	}, New[tA, tC](), m)
+}
+
+// See fp.MFilter(); entries are removed from m, so the result shares
+// structure with it
+func MFilter[
+	tF ~func(tA, tB) bool,
+	tA comparable,
+	tB any,
+](p tF, m PMap[tA, tB]) PMap[tA, tB] {
+	return MReduce(func(acc PMap[tA, tB], k tA, v tB) PMap[tA, tB] {
+		if p(k, v) {
+			return acc
+		}
+		return acc.Dissoc(k)
+	}, m, m)
+}
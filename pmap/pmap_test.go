@@ -0,0 +1,207 @@
+package pmap
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/loorke/fp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPMap(t *testing.T) {
+	m1 := FromMap(map[string]int{"a": 1, "b": 2})
+	m2 := m1.Assoc("c", 3).Assoc("a", 10)
+	m3 := m2.Dissoc("b").Dissoc("x")
+
+	require.Equal(t, map[string]int{"a": 1, "b": 2}, m1.ToMap())
+	require.Equal(t, map[string]int{"a": 10, "b": 2, "c": 3}, m2.ToMap())
+	require.Equal(t, map[string]int{"a": 10, "c": 3}, m3.ToMap())
+	require.Equal(t, 2, m3.Len())
+
+	v, ok := m2.Get("c")
+	require.True(t, ok)
+	require.Equal(t, 3, v)
+	require.False(t, m3.Contains("b"))
+
+	{
+		inc := func(old int, ok bool) int {
+			return fp.Cond(1, old+1)(ok)
+		}
+		m := m1.Update("a", inc).Update("z", inc)
+		require.Equal(t, map[string]int{"a": 2, "b": 2, "z": 1}, m.ToMap())
+	}
+
+	{
+		var e PMap[string, int]
+		require.Equal(t, 0, e.Len())
+		require.Equal(t, "{}", e.String())
+		require.Equal(t, e, e.Dissoc("a"))
+		require.Equal(t, "{a: 1}", e.Assoc("a", 1).String())
+		require.Equal(t, 0, e.Assoc("a", 1).Dissoc("a").Len())
+	}
+}
+
+// Random operations checked against a Go map
+func TestModel(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var m PMap[int, int]
+	model := map[int]int{}
+	snapshot, snapshotModel := m, map[int]int{}
+
+	for op := 0; op < 50000; op++ {
+		k := rnd.Intn(5000)
+		if rnd.Intn(3) > 0 {
+			m = m.Assoc(k, op)
+			model[k] = op
+		} else {
+			m = m.Dissoc(k)
+			delete(model, k)
+		}
+		require.Equal(t, len(model), m.Len())
+
+		if op == 20000 {
+			snapshot, snapshotModel = m, fp.MMapM(fp.Identity[int], model)
+		}
+	}
+
+	require.Equal(t, model, m.ToMap())
+	for k, v := range model {
+		got, ok := m.Get(k)
+		require.True(t, ok)
+		require.Equal(t, v, got)
+	}
+	require.Equal(t, snapshotModel, snapshot.ToMap())
+}
+
+func TestIterationOrder(t *testing.T) {
+	keys := fp.Map(strconv.Itoa, fp.RandShuffle(seq(1000)...)...)
+
+	var a, b PMap[string, int]
+	for _, k := range keys {
+		a = a.Assoc(k, 0)
+	}
+	for _, k := range fp.RandShuffle(keys...) {
+		b = b.Assoc(k, 0).Assoc(k+"x", 0)
+	}
+	for _, k := range keys {
+		b = b.Dissoc(k + "x")
+	}
+
+	require.Equal(t, a.Keys(), b.Keys())
+	require.ElementsMatch(t, keys, a.Keys())
+}
+
+func TestCollisions(t *testing.T) {
+	// Entries sharing the whole hash end up in a bucket
+	const h = 0xdeadbeef
+	var m PMap[string, int]
+	for i, k := range []string{"a", "b", "c"} {
+		m.root, _ = assoc(m.root, 0, entry[string, int]{h, k, i})
+		m.size++
+	}
+	m = m.Assoc("d", 3)
+
+	for i, k := range []string{"a", "b", "c"} {
+		v, ok := m.get(h, k)
+		require.True(t, ok)
+		require.Equal(t, i, v)
+	}
+	_, ok := m.get(h, "d")
+	require.False(t, ok)
+
+	root, removed := dissoc(m.root, 0, h, "b")
+	require.True(t, removed)
+	root, _ = dissoc(root, 0, h, "a")
+	m.root = root
+	v, ok := m.get(h, "c")
+	require.True(t, ok)
+	require.Equal(t, 2, v)
+	require.True(t, m.Contains("d"))
+}
+
+func TestCollisionOrder(t *testing.T) {
+	const h = 0xdeadbeef
+	build := func(keys ...string) PMap[string, int] {
+		var m PMap[string, int]
+		for _, k := range keys {
+			m.root, _ = assoc(m.root, 0, entry[string, int]{h, k, 0})
+		}
+		return m
+	}
+
+	want := []string{"a", "b", "c"}
+	require.Equal(t, want, build("a", "b", "c").Keys())
+	require.Equal(t, want, build("c", "a", "b").Keys())
+	require.Equal(t, want, build("b", "c", "a").Keys())
+}
+
+func TestHash(t *testing.T) {
+	type key struct {
+		s string
+		f float64
+		p *int
+		i any
+	}
+	x := 1
+
+	require.Equal(t, hashOf(0.0), hashOf(math.Copysign(0, -1)))
+	require.Equal(t,
+		hashOf(key{"a", 1, &x, 1}),
+		hashOf(key{"a", 1, &x, 1}))
+	require.NotEqual(t, hashOf([2]string{"ab", ""}), hashOf([2]string{"a", "b"}))
+
+	// Fast paths agree with the generic encoding
+	require.Equal(t, hashBytes(offset64, encodeKey("abc")), hashOf("abc"))
+	require.Equal(t, hashBytes(offset64, encodeKey(-7)), hashOf(-7))
+	require.Equal(t, hashBytes(offset64, encodeKey[any]("abc")), hashOf[any]("abc"))
+	require.Equal(t, hashBytes(offset64, encodeKey(int64(7))), hashOf(int64(7)))
+
+	m := New[key, int]().Assoc(key{"a", 0, &x, "i"}, 1)
+	require.True(t, m.Contains(key{"a", math.Copysign(0, -1), &x, "i"}))
+	require.False(t, m.Contains(key{"a", 0, &x, 1}))
+}
+
+func TestMFunctions(t *testing.T) {
+	src := map[string]int{"a": 1, "b": 2, "c": 3}
+	m := FromMap(src)
+
+	require.Equal(t,
+		fp.MReduce(func(acc int, _ string, v int) int { return acc + v }, 0, src),
+		MReduce(func(acc int, _ string, v int) int { return acc + v }, 0, m))
+
+	{
+		strs := MMap(strconv.Itoa, m)
+		sort.Strings(strs)
+		require.Equal(t, []string{"1", "2", "3"}, strs)
+		require.Equal(t, m.Keys(), MMapK(func(k string, _ int) string { return k }, m))
+		require.Len(t, m.Values(), 3)
+	}
+
+	require.Equal(t, fp.MMapM(strconv.Itoa, src), MMapM(strconv.Itoa, m).ToMap())
+
+	{
+		odd := func(_ string, v int) bool { return fp.IsOdd(v) }
+		require.Equal(t, fp.MFilter(odd, src), MFilter(odd, m).ToMap())
+	}
+
+	{
+		sum := func(_ string, old, new int) int { return old + new }
+		other := FromMap(map[string]int{"c": 10, "d": 4})
+		require.Equal(t,
+			fp.MMerge(sum, src, other.ToMap()),
+			Merge(sum, m, other).ToMap())
+		require.Equal(t, m, Merge(sum, New[string, int](), m))
+	}
+}
+
+// Returns [0, n)
+func seq(n int) []int {
+	res := make([]int, n)
+	for i := range res {
+		res[i] = i
+	}
+	return res
+}
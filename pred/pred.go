@@ -0,0 +1,187 @@
+/*
+Package pred provides self-describing predicates. Unlike plain func(T) bool
+predicates of package fp, they know how to print themselves, so combinations
+stay readable in error messages:
+
+	p := pred.And(pred.Between(1, 10), pred.Odd[int]())
+	p.String() // "in [1, 10] && odd"
+
+Predicates plug into fp through the Test method, and fp.MustBe() derives
+error messages from their descriptions:
+
+	fp.Filter(p.Test, 1, 2, 3)
+	fp.MustBe(p, 4) // panics: failure for value "4": in [1, 10] && odd
+
+Comparisons of package fp, such as fp.Gt() or fp.Includes(), deliberately
+stay plain funcs; the same-named constructors here are their described
+counterparts.
+*/
+package pred
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/loorke/fp"
+)
+
+type Predicate[tA any] struct {
+	desc string
+	test func(tA) bool
+	// Composite predicates are parenthesized when nested
+	composite bool
+}
+
+// Describes p with desc
+func Of[
+	tA any,
+	tF ~func(tA) bool,
+](desc string, p tF) Predicate[tA] {
+	return Predicate[tA]{desc: desc, test: p}
+}
+
+func (p Predicate[tA]) Test(v tA) bool {
+	return p.test(v)
+}
+
+func (p Predicate[tA]) String() string {
+	return p.desc
+}
+
+// Description suitable for embedding into a composite one
+func (p Predicate[tA]) operand() string {
+	if p.composite {
+		return "(" + p.desc + ")"
+	}
+	return p.desc
+}
+
+func combine[tA any](
+	op string,
+	ps []Predicate[tA],
+	test func(tA) bool,
+) Predicate[tA] {
+	descs := fp.Map(Predicate[tA].operand, ps...)
+	return Predicate[tA]{
+		desc:      strings.Join(descs, " "+op+" "),
+		test:      test,
+		composite: len(ps) > 1,
+	}
+}
+
+func Not[tA any](p Predicate[tA]) Predicate[tA] {
+	return Predicate[tA]{
+		desc: "not " + p.operand(),
+		test: fp.Not(p.test),
+	}
+}
+
+// Holds if all of ps hold; always holds if ps are empty
+func And[tA any](ps ...Predicate[tA]) Predicate[tA] {
+	return combine("&&", ps, func(v tA) bool {
+		return fp.All(func(p Predicate[tA]) bool {
+			return p.Test(v)
+		}, ps...)
+	})
+}
+
+// Holds if any of ps holds; never holds if ps are empty
+func Or[tA any](ps ...Predicate[tA]) Predicate[tA] {
+	return combine("||", ps, func(v tA) bool {
+		return fp.Any(func(p Predicate[tA]) bool {
+			return p.Test(v)
+		}, ps...)
+	})
+}
+
+// Holds if exactly one of a and b holds
+func Xor[tA any](a, b Predicate[tA]) Predicate[tA] {
+	return combine("^", []Predicate[tA]{a, b}, func(v tA) bool {
+		return a.Test(v) != b.Test(v)
+	})
+}
+
+// Holds if none of ps holds
+func NoneOf[tA any](ps ...Predicate[tA]) Predicate[tA] {
+	descs := fp.Map(Predicate[tA].String, ps...)
+	return Predicate[tA]{
+		desc: "none of [" + strings.Join(descs, ", ") + "]",
+		test: Not(Or(ps...)).test,
+	}
+}
+
+// b == a
+func Eq[tA comparable](a tA) Predicate[tA] {
+	return Of(fmt.Sprintf("== %v", a), fp.Eq(a))
+}
+
+// b != a
+func NEq[tA comparable](a tA) Predicate[tA] {
+	return Of(fmt.Sprintf("!= %v", a), fp.NEq(a))
+}
+
+// b < a
+func Lt[tA fp.Ordered](a tA) Predicate[tA] {
+	return Of(fmt.Sprintf("< %v", a), fp.Lt(a))
+}
+
+// b <= a
+func LtEq[tA fp.Ordered](a tA) Predicate[tA] {
+	return Of(fmt.Sprintf("<= %v", a), fp.LtEq(a))
+}
+
+// b > a
+func Gt[tA fp.Ordered](a tA) Predicate[tA] {
+	return Of(fmt.Sprintf("> %v", a), fp.Gt(a))
+}
+
+// b >= a
+func GtEq[tA fp.Ordered](a tA) Predicate[tA] {
+	return Of(fmt.Sprintf(">= %v", a), fp.GtEq(a))
+}
+
+func IsZero[tA comparable]() Predicate[tA] {
+	return Of("zero", fp.IsZero[tA])
+}
+
+func IsNotZero[tA comparable]() Predicate[tA] {
+	return Of("non-zero", fp.IsNotZero[tA])
+}
+
+// lo <= v <= hi
+func Between[tA fp.Ordered](lo, hi tA) Predicate[tA] {
+	return Of(fmt.Sprintf("in [%v, %v]", lo, hi), func(v tA) bool {
+		return lo <= v && v <= hi
+	})
+}
+
+// lo < v < hi
+func BetweenX[tA fp.Ordered](lo, hi tA) Predicate[tA] {
+	return Of(fmt.Sprintf("in (%v, %v)", lo, hi), func(v tA) bool {
+		return lo < v && v < hi
+	})
+}
+
+// See fp.Includes()
+func OneOf[tA comparable](a ...tA) Predicate[tA] {
+	return Of(fmt.Sprintf("one of %v", a), fp.Includes(a...))
+}
+
+// Same as OneOf()
+func Includes[tA comparable](a ...tA) Predicate[tA] {
+	return OneOf(a...)
+}
+
+func Odd[tA fp.IntegerNumber]() Predicate[tA] {
+	return Of("odd", fp.IsOdd[tA])
+}
+
+func Even[tA fp.IntegerNumber]() Predicate[tA] {
+	return Of("even", fp.IsEven[tA])
+}
+
+func MultipleOf[tA fp.IntegerNumber](n tA) Predicate[tA] {
+	return Of(fmt.Sprintf("multiple of %v", n), func(v tA) bool {
+		return v%n == 0
+	})
+}
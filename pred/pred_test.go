@@ -0,0 +1,90 @@
+package pred
+
+import (
+	"testing"
+
+	"github.com/loorke/fp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombinators(t *testing.T) {
+	small := Of("small", fp.Lt(10))
+
+	{
+		p := And(Between(1, 20), Odd[int]())
+		require.Equal(t, "in [1, 20] && odd", p.String())
+		require.Equal(t, []int{1, 3, 19}, fp.Filter(p.Test, 0, 1, 2, 3, 19, 21))
+	}
+
+	{
+		p := Or(And(small, Even[int]()), MultipleOf(5))
+		require.Equal(t, "(small && even) || multiple of 5", p.String())
+		require.Equal(t, []int{2, 4, 5, 15}, fp.Filter(p.Test, 2, 3, 4, 5, 15))
+	}
+
+	{
+		p := Xor(small, Even[int]())
+		require.Equal(t, "small ^ even", p.String())
+		require.Equal(t, []int{1, 12}, fp.Filter(p.Test, 1, 2, 11, 12))
+	}
+
+	{
+		p := NoneOf(small, OneOf(20, 30))
+		require.Equal(t, "none of [small, one of [20 30]]", p.String())
+		require.Equal(t, []int{15}, fp.Filter(p.Test, 1, 15, 20, 30))
+	}
+
+	{
+		p := Not(BetweenX(1.0, 2.0))
+		require.Equal(t, "not in (1, 2)", p.String())
+		require.True(t, p.Test(1))
+		require.False(t, p.Test(1.5))
+	}
+
+	{
+		require.True(t, And[int]().Test(0))
+		require.False(t, Or[int]().Test(0))
+		require.True(t, NoneOf[int]().Test(0))
+	}
+}
+
+func TestComparisons(t *testing.T) {
+	require.Equal(t, "> 5", Gt(5).String())
+	require.Equal(t, ">= 5", GtEq(5).String())
+	require.Equal(t, "< 5", Lt(5).String())
+	require.Equal(t, "<= 5", LtEq(5).String())
+	require.Equal(t, "== a", Eq("a").String())
+	require.Equal(t, "!= a", NEq("a").String())
+	require.Equal(t, "one of [1 2]", Includes(1, 2).String())
+	require.Equal(t, "zero || > 5", Or(IsZero[int](), Gt(5)).String())
+
+	require.True(t, fp.All(And(Gt(0), Lt(10)).Test, 1, 5, 9))
+	require.True(t, fp.All(IsNotZero[string]().Test, "a", "b"))
+}
+
+func TestMustMessage(t *testing.T) {
+	p := And(Between(1, 10), Even[int]())
+
+	func() {
+		defer func() {
+			me, ok := recover().(fp.MustError)
+			require.True(t, ok)
+			require.Equal(t,
+				`failure for value "3" at position 1: in [1, 10] && even`,
+				me.Error())
+		}()
+
+		fp.Must(p.Test, p.String(), 2, 3)
+	}()
+
+	func() {
+		defer func() {
+			me, ok := recover().(fp.MustError)
+			require.True(t, ok)
+			require.Equal(t, `failure for value "0": > 0`, me.Error())
+			require.Equal(t, Gt(0).String(), me.Pred.String())
+		}()
+
+		fp.MustBe(Gt(0), 0)
+	}()
+}
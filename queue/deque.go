@@ -0,0 +1,99 @@
+package queue
+
+// Double-ended queue; amortized O(1) pushes and pops at both ends, O(1)
+// indexed access. The zero value is an empty deque.
+type Deque[tA any] struct {
+	c circular[tA]
+}
+
+// Returns deque of a
+func NewDeque[tA any](a ...tA) *Deque[tA] {
+	d := &Deque[tA]{}
+	d.PushBack(a...)
+	return d
+}
+
+func (d *Deque[tA]) Len() int {
+	return d.c.n
+}
+
+// Number of elements d can hold before growing
+func (d *Deque[tA]) Cap() int {
+	return len(d.c.buf)
+}
+
+// Makes room for n more elements
+func (d *Deque[tA]) grow(n int) {
+	if d.c.n+n <= len(d.c.buf) {
+		return
+	}
+	capacity := max(len(d.c.buf), 8)
+	for capacity < d.c.n+n {
+		capacity *= 2
+	}
+	d.c.resize(capacity)
+}
+
+// Appends a to the back in order
+func (d *Deque[tA]) PushBack(a ...tA) {
+	d.grow(len(a))
+	for _, v := range a {
+		d.c.pushBack(v)
+	}
+}
+
+// Prepends a to the front one by one, so the last of a becomes the first
+func (d *Deque[tA]) PushFront(a ...tA) {
+	d.grow(len(a))
+	for _, v := range a {
+		d.c.pushFront(v)
+	}
+}
+
+// Removes the first element; ok is false if d is empty
+func (d *Deque[tA]) PopFront() (v tA, ok bool) {
+	return d.c.popFront()
+}
+
+// Removes the last element; ok is false if d is empty
+func (d *Deque[tA]) PopBack() (v tA, ok bool) {
+	return d.c.popBack()
+}
+
+// Returns the first element; ok is false if d is empty
+func (d *Deque[tA]) PeekFront() (v tA, ok bool) {
+	return d.c.peek(0)
+}
+
+// Returns the last element; ok is false if d is empty
+func (d *Deque[tA]) PeekBack() (v tA, ok bool) {
+	return d.c.peek(d.c.n - 1)
+}
+
+// Returns i-th element from the front; panics with MustError if i is out of
+// range
+func (d *Deque[tA]) At(i int) tA {
+	return d.c.at(i)
+}
+
+// Replaces i-th element from the front; panics with MustError if i is out of
+// range
+func (d *Deque[tA]) Set(i int, v tA) {
+	d.c.set(i, v)
+}
+
+// Removes all elements keeping the capacity
+func (d *Deque[tA]) Clear() {
+	d.c.clear()
+}
+
+// Returns contents front to back as up to two slices backed by d; they're
+// valid until d is modified
+func (d *Deque[tA]) Slices() (a, b []tA) {
+	return d.c.slices()
+}
+
+// Returns a copy of contents front to back
+func (d *Deque[tA]) Slice() []tA {
+	return d.c.slice()
+}
@@ -0,0 +1,142 @@
+/*
+Package queue provides a growable double-ended queue and a fixed-capacity
+ring buffer, both backed by circular slices. Unlike the rest of the library,
+they're mutable and not safe for concurrent use.
+
+Their contents are exposed as up to two contiguous slices, so they plug into
+functions of package fp without copying:
+
+	a, b := d.Slices()
+	fp.Sum(fp.Concat(a, b)...)
+
+or through Map, Filter and Reduce of this package.
+*/
+package queue
+
+import (
+	"github.com/loorke/fp"
+)
+
+// Contents of a queue in order, as a pair of contiguous slices
+type Sequence[tA any] interface {
+	Slices() (a, b []tA)
+}
+
+// Circular slice shared by Deque and RingBuffer; doesn't grow on its own
+type circular[tA any] struct {
+	buf  []tA
+	head int
+	n    int
+}
+
+// Position of i-th element in buf
+func (c *circular[tA]) pos(i int) int {
+	return (c.head + i) % len(c.buf)
+}
+
+// Checked by hand, so that the hot path doesn't allocate a predicate
+func (c *circular[tA]) checkIndex(i int) {
+	if i < 0 || i >= c.n {
+		fp.Must(fp.Const[bool, int](false), "index out of range", i)
+	}
+}
+
+func (c *circular[tA]) at(i int) tA {
+	c.checkIndex(i)
+	return c.buf[c.pos(i)]
+}
+
+func (c *circular[tA]) set(i int, v tA) {
+	c.checkIndex(i)
+	c.buf[c.pos(i)] = v
+}
+
+func (c *circular[tA]) pushBack(v tA) {
+	c.buf[c.pos(c.n)] = v
+	c.n++
+}
+
+func (c *circular[tA]) pushFront(v tA) {
+	c.head = (c.head - 1 + len(c.buf)) % len(c.buf)
+	c.buf[c.head] = v
+	c.n++
+}
+
+func (c *circular[tA]) popFront() (v tA, ok bool) {
+	if c.n == 0 {
+		return v, false
+	}
+	// Zeroed, so that the buffer doesn't keep popped values alive
+	v, c.buf[c.head] = c.buf[c.head], v
+	c.head = c.pos(1)
+	c.n--
+	return v, true
+}
+
+func (c *circular[tA]) popBack() (v tA, ok bool) {
+	if c.n == 0 {
+		return v, false
+	}
+	i := c.pos(c.n - 1)
+	v, c.buf[i] = c.buf[i], v
+	c.n--
+	return v, true
+}
+
+func (c *circular[tA]) peek(i int) (v tA, ok bool) {
+	if c.n == 0 {
+		return v, false
+	}
+	return c.buf[c.pos(i)], true
+}
+
+func (c *circular[tA]) slices() (a, b []tA) {
+	if c.head+c.n <= len(c.buf) {
+		return c.buf[c.head : c.head+c.n], nil
+	}
+	return c.buf[c.head:], c.buf[:c.head+c.n-len(c.buf)]
+}
+
+func (c *circular[tA]) slice() []tA {
+	return fp.Concat(c.slices())
+}
+
+func (c *circular[tA]) clear() {
+	clear(c.buf)
+	c.head, c.n = 0, 0
+}
+
+// Moves contents into a buffer of size capacity
+func (c *circular[tA]) resize(capacity int) {
+	buf := make([]tA, capacity)
+	a, b := c.slices()
+	copy(buf[copy(buf, a):], b)
+	c.buf, c.head = buf, 0
+}
+
+// Returns f applied to elements of s in order, see fp.Map()
+func Map[
+	tA, tB any,
+	tF ~func(tA) tB,
+](f tF, s Sequence[tA]) []tB {
+	a, b := s.Slices()
+	return append(fp.Map(f, a...), fp.Map(f, b...)...)
+}
+
+// Returns elements of s satisfying p in order, see fp.Filter()
+func Filter[
+	tA any,
+	tF ~func(tA) bool,
+](p tF, s Sequence[tA]) []tA {
+	a, b := s.Slices()
+	return append(fp.Filter(p, a...), fp.Filter(p, b...)...)
+}
+
+// See fp.Reduce()
+func Reduce[
+	tA, tB any,
+	tF ~func(tB, tA) tB,
+](f tF, z tB, s Sequence[tA]) tB {
+	a, b := s.Slices()
+	return fp.Reduce(f, fp.Reduce(f, z, a...), b...)
+}
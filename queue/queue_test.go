@@ -0,0 +1,160 @@
+package queue
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/loorke/fp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	_, ok := d.PopFront()
+	require.False(t, ok)
+	_, ok = d.PeekBack()
+	require.False(t, ok)
+	require.Empty(t, d.Slice())
+
+	d.PushBack(3, 4)
+	d.PushFront(2, 1)
+	require.Equal(t, []int{1, 2, 3, 4}, d.Slice())
+	require.Equal(t, 3, d.At(2))
+
+	{
+		v, _ := d.PeekFront()
+		require.Equal(t, 1, v)
+		v, _ = d.PeekBack()
+		require.Equal(t, 4, v)
+	}
+
+	{
+		v, ok := d.PopBack()
+		require.True(t, ok)
+		require.Equal(t, 4, v)
+		v, _ = d.PopFront()
+		require.Equal(t, 1, v)
+		require.Equal(t, []int{2, 3}, d.Slice())
+	}
+
+	d.Set(0, 20)
+	require.Equal(t, []int{20, 3}, d.Slice())
+	require.Panics(t, func() { d.At(2) })
+	require.Panics(t, func() { d.Set(-1, 0) })
+	require.Zero(t, testing.AllocsPerRun(100, func() { d.At(1) }))
+
+	d.Clear()
+	require.Equal(t, 0, d.Len())
+	require.Equal(t, 8, d.Cap())
+}
+
+func TestDequeGrowth(t *testing.T) {
+	d := NewDeque[int]()
+	// Wrap around before growing
+	for i := 0; i < 6; i++ {
+		d.PushBack(i)
+	}
+	for i := 0; i < 5; i++ {
+		d.PopFront()
+	}
+	for i := 6; i < 100; i++ {
+		d.PushBack(i)
+	}
+	require.Equal(t, 95, d.Len())
+	require.Equal(t, 128, d.Cap())
+	for i := 0; i < d.Len(); i++ {
+		require.Equal(t, i+5, d.At(i))
+	}
+}
+
+// Random operations checked against a slice model
+func TestDequeModel(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	d := NewDeque[int]()
+	var model []int
+	for op := 0; op < 10000; op++ {
+		switch rnd.Intn(4) {
+		case 0:
+			d.PushBack(op)
+			model = append(model, op)
+		case 1:
+			d.PushFront(op)
+			model = append([]int{op}, model...)
+		case 2:
+			v, ok := d.PopBack()
+			require.Equal(t, len(model) > 0, ok)
+			if ok {
+				require.Equal(t, model[len(model)-1], v)
+				model = model[:len(model)-1]
+			}
+		case 3:
+			v, ok := d.PopFront()
+			require.Equal(t, len(model) > 0, ok)
+			if ok {
+				require.Equal(t, model[0], v)
+				model = model[1:]
+			}
+		}
+	}
+	require.Equal(t, model, append([]int{}, d.Slice()...))
+}
+
+func TestRingBuffer(t *testing.T) {
+	{
+		r := NewRingBuffer[int](3, false)
+		require.True(t, r.Push(1))
+		require.True(t, r.Push(2))
+		require.True(t, r.Push(3))
+		require.True(t, r.Full())
+		require.False(t, r.Push(4))
+		require.Equal(t, []int{1, 2, 3}, r.Slice())
+
+		v, ok := r.Pop()
+		require.True(t, ok)
+		require.Equal(t, 1, v)
+		require.True(t, r.Push(4))
+		require.Equal(t, []int{2, 3, 4}, r.Slice())
+		require.Equal(t, 3, r.At(1))
+	}
+
+	{
+		r := NewRingBuffer[int](3, true)
+		for i := 1; i <= 5; i++ {
+			require.True(t, r.Push(i))
+		}
+		require.Equal(t, []int{3, 4, 5}, r.Slice())
+		require.Equal(t, 3, r.Len())
+
+		oldest, _ := r.Peek()
+		newest, _ := r.PeekNewest()
+		require.Equal(t, 3, oldest)
+		require.Equal(t, 5, newest)
+
+		// Contents wrap around the end of the buffer
+		a, b := r.Slices()
+		require.Equal(t, []int{3}, a)
+		require.Equal(t, []int{4, 5}, b)
+
+		r.Clear()
+		_, ok := r.Peek()
+		require.False(t, ok)
+	}
+
+	require.Panics(t, func() { NewRingBuffer[int](0, true) })
+}
+
+func TestMapFilterReduce(t *testing.T) {
+	r := NewRingBuffer[int](4, true)
+	for i := 1; i <= 6; i++ {
+		r.Push(i)
+	}
+	d := NewDeque(4, 5)
+	d.PushFront(3)
+
+	require.Equal(t, []int{4, 6}, Filter(fp.IsEven[int], r))
+	require.Equal(t, []int{4}, Filter(fp.IsEven[int], d))
+
+	require.Equal(t, []string{"3", "4", "5", "6"}, Map(strconv.Itoa, r))
+	require.Equal(t, 12, Reduce(fp.Apply2(fp.Sum[int]), 0, d))
+}
@@ -0,0 +1,80 @@
+package queue
+
+import "github.com/loorke/fp"
+
+// Queue of fixed capacity. Once it's full, pushes either fail or, in
+// overwrite mode, evict the oldest element.
+type RingBuffer[tA any] struct {
+	c         circular[tA]
+	overwrite bool
+}
+
+// Panics with MustError if capacity isn't positive
+func NewRingBuffer[tA any](capacity int, overwrite bool) *RingBuffer[tA] {
+	fp.Must(fp.Gt(0), "capacity must be positive", capacity)
+	return &RingBuffer[tA]{
+		c:         circular[tA]{buf: make([]tA, capacity)},
+		overwrite: overwrite,
+	}
+}
+
+func (r *RingBuffer[tA]) Len() int {
+	return r.c.n
+}
+
+func (r *RingBuffer[tA]) Cap() int {
+	return len(r.c.buf)
+}
+
+func (r *RingBuffer[tA]) Full() bool {
+	return r.c.n == len(r.c.buf)
+}
+
+// Appends v as the newest element. If r is full, v is dropped and false is
+// returned, unless r is in overwrite mode and evicts the oldest one.
+func (r *RingBuffer[tA]) Push(v tA) bool {
+	if r.Full() {
+		if !r.overwrite {
+			return false
+		}
+		r.c.popFront()
+	}
+	r.c.pushBack(v)
+	return true
+}
+
+// Removes the oldest element; ok is false if r is empty
+func (r *RingBuffer[tA]) Pop() (v tA, ok bool) {
+	return r.c.popFront()
+}
+
+// Returns the oldest element; ok is false if r is empty
+func (r *RingBuffer[tA]) Peek() (v tA, ok bool) {
+	return r.c.peek(0)
+}
+
+// Returns the newest element; ok is false if r is empty
+func (r *RingBuffer[tA]) PeekNewest() (v tA, ok bool) {
+	return r.c.peek(r.c.n - 1)
+}
+
+// Returns i-th element from the oldest; panics with MustError if i is out
+// of range
+func (r *RingBuffer[tA]) At(i int) tA {
+	return r.c.at(i)
+}
+
+func (r *RingBuffer[tA]) Clear() {
+	r.c.clear()
+}
+
+// Returns contents oldest to newest as up to two slices backed by r;
+// they're valid until r is modified
+func (r *RingBuffer[tA]) Slices() (a, b []tA) {
+	return r.c.slices()
+}
+
+// Returns a copy of contents oldest to newest
+func (r *RingBuffer[tA]) Slice() []tA {
+	return r.c.slice()
+}
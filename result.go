@@ -0,0 +1,63 @@
+package fp
+
+//////////
+/// Results
+
+// Outcome of a fallible computation, i.e. (tA, error) as a single value
+type Result[tA any] struct {
+	Val tA
+	Err error
+}
+
+func ResultOf[tA any](v tA, err error) Result[tA] {
+	return Result[tA]{Val: v, Err: err}
+}
+
+func Ok[tA any](v tA) Result[tA] {
+	return Result[tA]{Val: v}
+}
+
+func Fail[tA any](err error) Result[tA] {
+	return Result[tA]{Err: err}
+}
+
+func (r Result[tA]) IsOk() bool {
+	return r.Err == nil
+}
+
+func (r Result[tA]) Get() (tA, error) {
+	return r.Val, r.Err
+}
+
+// Returns the value; panics with MustError if r is failed, see NoError()
+func (r Result[tA]) Must() tA {
+	return NoError(r.Val, r.Err)
+}
+
+// Returns the value or v if r is failed
+func (r Result[tA]) Or(v tA) tA {
+	return Cond(v, r.Val)(r.IsOk())
+}
+
+// Applies f to the value of r; failures are passed through
+func MapResult[
+	tA, tB any,
+	tF ~func(tA) tB,
+](f tF, r Result[tA]) Result[tB] {
+	if r.Err != nil {
+		return Fail[tB](r.Err)
+	}
+	return Ok(f(r.Val))
+}
+
+// Splits rs into values and errors of failed ones, keeping their order
+func PartitionResults[tA any](rs ...Result[tA]) (vals []tA, errs []error) {
+	for _, r := range rs {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		} else {
+			vals = append(vals, r.Val)
+		}
+	}
+	return vals, errs
+}
@@ -0,0 +1,37 @@
+package fp
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResult(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	{
+		r := ResultOf(strconv.Atoi("42"))
+		require.True(t, r.IsOk())
+		require.Equal(t, 42, r.Must())
+		require.Equal(t, 42, r.Or(0))
+		require.Equal(t, Ok("42"), MapResult(strconv.Itoa, r))
+	}
+
+	{
+		r := Fail[int](errBoom)
+		require.False(t, r.IsOk())
+		require.Equal(t, -1, r.Or(-1))
+		require.Panics(t, func() { r.Must() })
+
+		_, err := MapResult(strconv.Itoa, r).Get()
+		require.ErrorIs(t, err, errBoom)
+	}
+
+	{
+		vals, errs := PartitionResults(Ok(1), Fail[int](errBoom), Ok(3))
+		require.Equal(t, []int{1, 3}, vals)
+		require.Equal(t, []error{errBoom}, errs)
+	}
+}
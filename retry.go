@@ -0,0 +1,170 @@
+package fp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+//////////
+/// Retries
+
+// Returns delay before the next attempt given the number of failed ones so
+// far, starting with 1
+type Backoff func(failed int) time.Duration
+
+// Waits d between attempts
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// Doubles the delay starting with base up to limit. Each delay is then
+// reduced by a random fraction of up to jitter, which is within [0, 1]; rnd
+// is the source of randomness, the global one of math/rand is used if it's
+// nil. Note that *rand.Rand isn't safe for concurrent use.
+func ExponentialBackoff(
+	base, limit time.Duration,
+	jitter float64,
+	rnd *rand.Rand,
+) Backoff {
+	Must(func(j float64) bool {
+		return 0 <= j && j <= 1
+	}, "jitter must be within [0, 1]", jitter)
+
+	random := rand.Float64
+	if rnd != nil {
+		random = rnd.Float64
+	}
+
+	return func(failed int) time.Duration {
+		d := base
+		for i := 1; i < failed && d < limit; i++ {
+			d *= 2
+		}
+		// Doubling may overflow as well
+		if d > limit || d <= 0 {
+			d = limit
+		}
+		if jitter > 0 {
+			d -= time.Duration(float64(d) * jitter * random())
+		}
+		return d
+	}
+}
+
+// Retry policy; zero value retries any error immediately and indefinitely
+type RetryPolicy struct {
+	// Delay between attempts; none if nil
+	Backoff Backoff
+	// Limit on the number of attempts including the first one; unlimited if
+	// not positive
+	MaxAttempts int
+	// Limit on the time since the first attempt; no retry is started if the
+	// backoff would exceed it. Unlimited if not positive.
+	MaxElapsed time.Duration
+	// Decides whether an error is worth retrying; any error is if nil
+	RetryIf func(error) bool
+	// SystemClock is used if nil
+	Clock Clock
+}
+
+// Returned by Retry() when it gives up on a retriable error
+type RetryError struct {
+	Attempts int
+	// The last error of f, joined with ctx.Err() if ctx is done
+	Err error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Calls f until it succeeds or p gives up, waiting between attempts
+// according to p. Errors rejected by p.RetryIf are returned as is, others
+// are wrapped into *RetryError once attempts are exhausted or ctx is done.
+func Retry[
+	tA any,
+	tF ~func() (tA, error),
+](ctx context.Context, f tF, p RetryPolicy) (tA, error) {
+	clock := p.Clock
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	start := clock.Now()
+	if err := ctx.Err(); err != nil {
+		return Zero[tA](), err
+	}
+
+	for attempt := 1; ; attempt++ {
+		v, err := f()
+		if err == nil {
+			return v, nil
+		}
+		if p.RetryIf != nil && !p.RetryIf(err) {
+			return Zero[tA](), err
+		}
+
+		giveUp := func(cause error) (tA, error) {
+			if cause != nil {
+				err = errors.Join(err, cause)
+			}
+			return Zero[tA](), &RetryError{Attempts: attempt, Err: err}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return giveUp(err)
+		}
+
+		if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+			return giveUp(nil)
+		}
+
+		var delay time.Duration
+		if p.Backoff != nil {
+			delay = p.Backoff(attempt)
+		}
+		if p.MaxElapsed > 0 && clock.Now().Add(delay).Sub(start) > p.MaxElapsed {
+			return giveUp(nil)
+		}
+		if delay > 0 {
+			if err := sleep(ctx, clock, delay); err != nil {
+				return giveUp(err)
+			}
+		}
+	}
+}
+
+// Same as Retry(), but for functions taking context
+func RetryCtx[
+	tA any,
+	tF ~func(context.Context) (tA, error),
+](ctx context.Context, f tF, p RetryPolicy) (tA, error) {
+	return Retry(ctx, func() (tA, error) {
+		return f(ctx)
+	}, p)
+}
+
+// Returns predicate matching errors with errors.Is(), e.g. for RetryIf:
+//
+//	RetryIf: fp.Not(fp.ErrorIs(ErrPermanent))
+func ErrorIs(target error) func(error) bool {
+	return func(err error) bool {
+		return errors.Is(err, target)
+	}
+}
+
+// Returns predicate matching errors with errors.As()
+func ErrorAs[tE error]() func(error) bool {
+	return func(err error) bool {
+		var target tE
+		return errors.As(err, &target)
+	}
+}
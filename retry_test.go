@@ -0,0 +1,157 @@
+package fp
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Fails with errs in turn, then succeeds with the number of calls
+func failing(errs ...error) (f func() (int, error), calls *int) {
+	calls = new(int)
+	return func() (int, error) {
+		*calls++
+		if *calls <= len(errs) {
+			return 0, errs[*calls-1]
+		}
+		return *calls, nil
+	}, calls
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, time.Second, ConstantBackoff(time.Second)(5))
+
+	{
+		b := ExponentialBackoff(time.Second, 10*time.Second, 0, nil)
+		require.Equal(t,
+			[]time.Duration{1, 2, 4, 8, 10, 10},
+			Map(func(i int) time.Duration {
+				return b(i) / time.Second
+			}, 1, 2, 3, 4, 5, 100))
+	}
+
+	{
+		b := ExponentialBackoff(time.Second, time.Minute, 0.5, rand.New(rand.NewSource(1)))
+		for i := 1; i <= 10; i++ {
+			full := ExponentialBackoff(time.Second, time.Minute, 0, nil)(i)
+			require.LessOrEqual(t, b(i), full)
+			require.GreaterOrEqual(t, b(i), full/2)
+		}
+
+		// Same seed, same delays
+		b1 := ExponentialBackoff(time.Second, time.Minute, 1, rand.New(rand.NewSource(7)))
+		b2 := ExponentialBackoff(time.Second, time.Minute, 1, rand.New(rand.NewSource(7)))
+		require.Equal(t, Map(b1, 1, 2, 3), Map(b2, 1, 2, 3))
+	}
+
+	require.Panics(t, func() { ExponentialBackoff(1, 2, 1.5, nil) })
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	errTemp := errors.New("temporary")
+	errFatal := errors.New("fatal")
+
+	{
+		clock := newFakeClock()
+		f, calls := failing(errTemp, errTemp)
+		v, err := Retry(ctx, f, RetryPolicy{
+			Backoff: ExponentialBackoff(time.Second, time.Minute, 0, nil),
+			Clock:   clock,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, v)
+		require.Equal(t, 3, *calls)
+		require.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.Sleeps())
+	}
+
+	{
+		f, calls := failing(errTemp, errTemp, errTemp)
+		_, err := Retry(ctx, f, RetryPolicy{MaxAttempts: 2})
+		require.ErrorIs(t, err, errTemp)
+
+		var re *RetryError
+		require.ErrorAs(t, err, &re)
+		require.Equal(t, 2, re.Attempts)
+		require.Equal(t, 2, *calls)
+	}
+
+	{
+		clock := newFakeClock()
+		f, calls := failing(errTemp, errTemp, errTemp, errTemp)
+		_, err := Retry(ctx, f, RetryPolicy{
+			Backoff:    ConstantBackoff(time.Minute),
+			MaxElapsed: 150 * time.Second,
+			Clock:      clock,
+		})
+		require.ErrorIs(t, err, errTemp)
+		require.Equal(t, 3, *calls)
+		require.Equal(t, []time.Duration{time.Minute, time.Minute}, clock.Sleeps())
+	}
+
+	{
+		f, calls := failing(errTemp, errFatal, errTemp)
+		_, err := Retry(ctx, f, RetryPolicy{
+			RetryIf: Not(ErrorIs(errFatal)),
+		})
+		require.Equal(t, errFatal, err)
+		require.Equal(t, 2, *calls)
+	}
+
+	{
+		f, _ := failing(&fs.PathError{Err: errTemp}, errFatal)
+		_, err := Retry(ctx, f, RetryPolicy{
+			RetryIf: ErrorAs[*fs.PathError](),
+		})
+		require.Equal(t, errFatal, err)
+	}
+}
+
+func TestRetryCtx(t *testing.T) {
+	errTemp := errors.New("temporary")
+
+	{
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		f, calls := failing()
+		_, err := Retry(ctx, f, RetryPolicy{})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 0, *calls)
+	}
+
+	{
+		// Real clock; cancelled while waiting for the second attempt
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		calls := 0
+		_, err := RetryCtx(ctx, func(ctx context.Context) (int, error) {
+			calls++
+			return 0, errTemp
+		}, RetryPolicy{Backoff: ConstantBackoff(time.Hour)})
+		require.ErrorIs(t, err, errTemp)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, 1, calls)
+	}
+
+	{
+		// Cancelled by f itself; retried right away without backoff
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		_, err := Retry(ctx, func() (int, error) {
+			calls++
+			cancel()
+			return 0, errTemp
+		}, RetryPolicy{})
+		var re *RetryError
+		require.ErrorAs(t, err, &re)
+		require.Equal(t, 1, re.Attempts)
+		require.ErrorIs(t, err, errTemp)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 1, calls)
+	}
+}
@@ -0,0 +1,177 @@
+package fp
+
+import (
+	"cmp"
+	"slices"
+)
+
+//////////
+/// Sorting
+
+// Comparator returns a negative number if a < b, a positive number if a > b
+// and zero if they are equal.
+type Comparator[tA any] func(a, b tA) int
+
+// Natural order comparator; NaNs are ordered before any other float.
+func Compare[tA Ordered](a, b tA) int {
+	return cmp.Compare(a, b)
+}
+
+// Compares values by the key extracted with key
+func Comparing[
+	tA any,
+	tB Ordered,
+	tF ~func(tA) tB,
+](key tF) Comparator[tA] {
+	return func(a, b tA) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// Breaks ties of c with next
+func (c Comparator[tA]) ThenComparing(next Comparator[tA]) Comparator[tA] {
+	return func(a, b tA) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// Reverses the order of c
+func (c Comparator[tA]) Reversed() Comparator[tA] {
+	return func(a, b tA) int {
+		return c(b, a)
+	}
+}
+
+// Lifts c to pointers, nil pointers go first
+func NilsFirst[tA any](c Comparator[tA]) Comparator[*tA] {
+	return func(a, b *tA) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return 1
+		default:
+			return c(*a, *b)
+		}
+	}
+}
+
+// Lifts c to pointers, nil pointers go last
+func NilsLast[tA any](c Comparator[tA]) Comparator[*tA] {
+	return NilsFirst(c.Reversed()).Reversed()
+}
+
+// Returns sorted copy of a
+func Sorted[tA Ordered](a ...tA) []tA {
+	res := slices.Clone(a)
+	Sort_(res)
+	return res
+}
+
+// Sorts a in place
+func Sort_[tS ~[]tA, tA Ordered](a tS) {
+	slices.Sort(a)
+}
+
+// Returns copy of a sorted with c
+func SortWith[tA any](c Comparator[tA], a ...tA) []tA {
+	res := slices.Clone(a)
+	SortWith_(c, res)
+	return res
+}
+
+// Sorts a in place with c
+func SortWith_[tS ~[]tA, tA any](c Comparator[tA], a tS) {
+	slices.SortFunc(a, c)
+}
+
+// Returns copy of a stably sorted with c
+func SortStableWith[tA any](c Comparator[tA], a ...tA) []tA {
+	res := slices.Clone(a)
+	SortStableWith_(c, res)
+	return res
+}
+
+// Stably sorts a in place with c
+func SortStableWith_[tS ~[]tA, tA any](c Comparator[tA], a tS) {
+	slices.SortStableFunc(a, c)
+}
+
+// Returns copy of a sorted in ascending order of key
+func SortBy[
+	tA any,
+	tB Ordered,
+	tF ~func(tA) tB,
+](key tF, a ...tA) []tA {
+	return SortWith(Comparing(key), a...)
+}
+
+// In-place version of SortBy()
+func SortBy_[
+	tS ~[]tA,
+	tA any,
+	tB Ordered,
+	tF ~func(tA) tB,
+](key tF, a tS) {
+	SortWith_(Comparing(key), a)
+}
+
+// Returns copy of a sorted in descending order of key
+func SortByDesc[
+	tA any,
+	tB Ordered,
+	tF ~func(tA) tB,
+](key tF, a ...tA) []tA {
+	return SortWith(Comparing(key).Reversed(), a...)
+}
+
+// In-place version of SortByDesc()
+func SortByDesc_[
+	tS ~[]tA,
+	tA any,
+	tB Ordered,
+	tF ~func(tA) tB,
+](key tF, a tS) {
+	SortWith_(Comparing(key).Reversed(), a)
+}
+
+// Returns copy of a sorted in ascending order of key, equal elements keep
+// their original order
+func SortStableBy[
+	tA any,
+	tB Ordered,
+	tF ~func(tA) tB,
+](key tF, a ...tA) []tA {
+	return SortStableWith(Comparing(key), a...)
+}
+
+// In-place version of SortStableBy()
+func SortStableBy_[
+	tS ~[]tA,
+	tA any,
+	tB Ordered,
+	tF ~func(tA) tB,
+](key tF, a tS) {
+	SortStableWith_(Comparing(key), a)
+}
+
+func IsSorted[tA Ordered](a ...tA) bool {
+	return slices.IsSorted(a)
+}
+
+func IsSortedWith[tA any](c Comparator[tA], a ...tA) bool {
+	return slices.IsSortedFunc(a, c)
+}
+
+func IsSortedBy[
+	tA any,
+	tB Ordered,
+	tF ~func(tA) tB,
+](key tF, a ...tA) bool {
+	return IsSortedWith(Comparing(key), a...)
+}
@@ -0,0 +1,95 @@
+package fp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSorted(t *testing.T) {
+	a := []int{3, 1, 2}
+	require.Equal(t, []int{1, 2, 3}, Sorted(a...))
+	require.Equal(t, []int{3, 1, 2}, a)
+
+	Sort_(a)
+	require.Equal(t, []int{1, 2, 3}, a)
+
+	require.True(t, IsSorted(a...))
+	require.True(t, IsSorted[int]())
+	require.False(t, IsSorted(2, 1))
+}
+
+func TestSortBy(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	users := []user{
+		{"bob", 30},
+		{"alice", 25},
+		{"carol", 30},
+		{"dave", 25},
+	}
+	age := func(u user) int { return u.Age }
+	name := func(u user) string { return u.Name }
+
+	{
+		res := SortStableBy(age, users...)
+		require.Equal(t, []user{
+			{"alice", 25},
+			{"dave", 25},
+			{"bob", 30},
+			{"carol", 30},
+		}, res)
+		require.True(t, IsSortedBy(age, res...))
+	}
+
+	{
+		res := SortByDesc(name, users...)
+		require.Equal(t, []string{"dave", "carol", "bob", "alice"},
+			Map(name, res...))
+	}
+
+	{
+		c := Comparing(age).Reversed().ThenComparing(Comparing(name))
+		res := SortWith(c, users...)
+		require.Equal(t, []user{
+			{"bob", 30},
+			{"carol", 30},
+			{"alice", 25},
+			{"dave", 25},
+		}, res)
+		require.True(t, IsSortedWith(c, res...))
+	}
+
+	{
+		a := []string{"ccc", "a", "bb"}
+		SortBy_(func(s string) int { return len(s) }, a)
+		require.Equal(t, []string{"a", "bb", "ccc"}, a)
+
+		SortByDesc_(strings.ToUpper, a)
+		require.Equal(t, []string{"ccc", "bb", "a"}, a)
+	}
+}
+
+func TestNilsFirst(t *testing.T) {
+	a := []*int{Ref(2), nil, Ref(1), nil}
+
+	{
+		res := SortWith(NilsFirst(Compare[int]), a...)
+		require.Nil(t, res[0])
+		require.Nil(t, res[1])
+		require.Equal(t, 1, *res[2])
+		require.Equal(t, 2, *res[3])
+	}
+
+	{
+		res := SortWith(NilsLast(Compare[int]), a...)
+		require.Equal(t, 1, *res[0])
+		require.Equal(t, 2, *res[1])
+		require.Nil(t, res[2])
+		require.Nil(t, res[3])
+	}
+}
@@ -0,0 +1,154 @@
+package fp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+//////////
+/// Throttling
+
+// Returned for every item of a batch whose function returned a wrong number
+// of outputs
+var ErrBatchSize = errors.New("batch output size mismatch")
+
+// Token bucket allowing rps events per second on average with bursts of up
+// to burst events. Safe for concurrent use.
+type RateLimiter struct {
+	clock    Clock
+	interval time.Duration
+	// Allowed lead of tat over the current time
+	tolerance time.Duration
+
+	mu sync.Mutex
+	// Theoretical arrival time of the next event
+	tat time.Time
+}
+
+// SystemClock is used if clock is nil
+func NewRateLimiter(rps float64, burst int, clock Clock) *RateLimiter {
+	Must(Gt(0.), "rate must be positive", rps)
+	Must(Gt(0), "burst must be positive", burst)
+	if clock == nil {
+		clock = SystemClock{}
+	}
+
+	interval := time.Duration(float64(time.Second) / rps)
+	return &RateLimiter{
+		clock:     clock,
+		interval:  interval,
+		tolerance: time.Duration(burst-1) * interval,
+	}
+}
+
+// Reserves the next event and returns how long to wait for it
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if l.tat.Before(now) {
+		l.tat = now
+	}
+	wait := l.tat.Sub(now) - l.tolerance
+	l.tat = l.tat.Add(l.interval)
+	return max(wait, 0)
+}
+
+// Blocks until an event is allowed; returns ctx.Err() if ctx is done first,
+// the reserved event is lost in such a case
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if wait := l.reserve(); wait > 0 {
+		return sleep(ctx, l.clock, wait)
+	}
+	return ctx.Err()
+}
+
+// Calls f for each of a concurrently, starting at most rps calls per second
+// with bursts of up to burst. Results are in the order of a.
+func MapRateLimited[
+	tA, tB any,
+	tF ~func(tA) (tB, error),
+](f tF, rps float64, burst int, a ...tA) []Result[tB] {
+	return MapRateLimitedWith(NewRateLimiter(rps, burst, nil), f, a...)
+}
+
+// Same as MapRateLimited(), but paced by l, which may be shared among calls
+func MapRateLimitedWith[
+	tA, tB any,
+	tF ~func(tA) (tB, error),
+](l *RateLimiter, f tF, a ...tA) []Result[tB] {
+	futs := make([]*Future[tB], len(a))
+	for i, v := range a {
+		v := v
+		// Calls are started one by one, so waits don't overlap
+		l.Wait(context.Background())
+		futs[i] = Go(func() (tB, error) {
+			return f(v)
+		})
+	}
+	return awaitAll(futs...)
+}
+
+// Calls f concurrently for chunks of a of up to size elements and stitches
+// its outputs together in the order of a. f must return an output per
+// input; otherwise, or if f panics, every item of the chunk fails.
+func MapBatched[
+	tA, tB any,
+	tF ~func([]tA) []tB,
+](f tF, size int, a ...tA) []Result[tB] {
+	return mapBatched(nil, f, size, a...)
+}
+
+// Same as MapBatched(), but every call of f is paced by l
+func MapBatchedWith[
+	tA, tB any,
+	tF ~func([]tA) []tB,
+](l *RateLimiter, f tF, size int, a ...tA) []Result[tB] {
+	return mapBatched(l, f, size, a...)
+}
+
+func mapBatched[
+	tA, tB any,
+	tF ~func([]tA) []tB,
+](l *RateLimiter, f tF, size int, a ...tA) []Result[tB] {
+	Must(Gt(0), "batch size must be positive", size)
+
+	var futs []*Future[[]tB]
+	for lo := 0; lo < len(a); lo += size {
+		chunk := a[lo:min(lo+size, len(a)):min(lo+size, len(a))]
+		if l != nil {
+			l.Wait(context.Background())
+		}
+		futs = append(futs, Go(func() ([]tB, error) {
+			out := f(chunk)
+			if len(out) != len(chunk) {
+				return nil, fmt.Errorf("%w: %d inputs, %d outputs",
+					ErrBatchSize, len(chunk), len(out))
+			}
+			return out, nil
+		}))
+	}
+
+	res := make([]Result[tB], 0, len(a))
+	for i, r := range awaitAll(futs...) {
+		n := min(size, len(a)-i*size)
+		for j := 0; j < n; j++ {
+			if r.Err != nil {
+				res = append(res, Fail[tB](r.Err))
+			} else {
+				res = append(res, Ok(r.Val[j]))
+			}
+		}
+	}
+	return res
+}
+
+func awaitAll[tA any](futs ...*Future[tA]) []Result[tA] {
+	return Map(func(fut *Future[tA]) Result[tA] {
+		return fut.Result(context.Background())
+	}, futs...)
+}
@@ -0,0 +1,122 @@
+package fp
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	{
+		clock := newFakeClock()
+		l := NewRateLimiter(10, 3, clock)
+		for i := 0; i < 6; i++ {
+			require.NoError(t, l.Wait(ctx))
+		}
+		// Burst is free, then 100ms apart
+		require.Equal(t, []time.Duration{
+			100 * time.Millisecond,
+			100 * time.Millisecond,
+			100 * time.Millisecond,
+		}, clock.Sleeps())
+
+		// Idle time refills the bucket, but not beyond burst
+		clock.Advance(time.Hour)
+		for i := 0; i < 4; i++ {
+			require.NoError(t, l.Wait(ctx))
+		}
+		require.Len(t, clock.Sleeps(), 4)
+	}
+
+	{
+		l := NewRateLimiter(1, 1, nil)
+		require.NoError(t, l.Wait(ctx))
+
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+	}
+
+	require.Panics(t, func() { NewRateLimiter(0, 1, nil) })
+	require.Panics(t, func() { NewRateLimiter(1, 0, nil) })
+}
+
+func TestMapRateLimited(t *testing.T) {
+	errOdd := errors.New("odd")
+	f := func(v int) (string, error) {
+		if IsOdd(v) {
+			return "", errOdd
+		}
+		return strconv.Itoa(v), nil
+	}
+
+	{
+		clock := newFakeClock()
+		res := MapRateLimitedWith(NewRateLimiter(2, 1, clock), f, 1, 2, 3, 4)
+		require.Equal(t, []Result[string]{
+			Fail[string](errOdd), Ok("2"), Fail[string](errOdd), Ok("4"),
+		}, res)
+		require.Equal(t, 1500*time.Millisecond, Sum(clock.Sleeps()...))
+	}
+
+	{
+		res := MapRateLimited(f, 1000, 10, 2, 4)
+		require.Equal(t, []Result[string]{Ok("2"), Ok("4")}, res)
+		require.Empty(t, MapRateLimited(f, 1, 1))
+	}
+
+	{
+		res := MapRateLimited(func(int) (int, error) {
+			panic("boom")
+		}, 1000, 1, 1)
+		require.ErrorIs(t, res[0].Err, ErrFuturePanic)
+	}
+}
+
+func TestMapBatched(t *testing.T) {
+	var calls atomic.Int32
+	strs := func(a []int) []string {
+		calls.Add(1)
+		return Map(strconv.Itoa, a...)
+	}
+
+	{
+		res := MapBatched(strs, 2, 1, 2, 3, 4, 5)
+		require.Equal(t,
+			Map(Ok[string], "1", "2", "3", "4", "5"),
+			res)
+		require.Equal(t, int32(3), calls.Load())
+		require.Empty(t, MapBatched(strs, 2))
+	}
+
+	{
+		// The chunk of 3 and 4 is short of an output
+		res := MapBatched(func(a []int) []int {
+			if a[0] == 3 {
+				return a[:1]
+			}
+			return a
+		}, 2, 1, 2, 3, 4, 5)
+		vals, errs := PartitionResults(res...)
+		require.Equal(t, []int{1, 2, 5}, vals)
+		require.Len(t, errs, 2)
+		require.ErrorIs(t, res[2].Err, ErrBatchSize)
+		require.ErrorIs(t, res[3].Err, ErrBatchSize)
+	}
+
+	{
+		clock := newFakeClock()
+		res := MapBatchedWith(NewRateLimiter(1, 1, clock), strs, 1, 1, 2, 3)
+		require.Len(t, res, 3)
+		require.Equal(t, []time.Duration{time.Second, time.Second}, clock.Sleeps())
+	}
+
+	require.Panics(t, func() { MapBatched(strs, 0, 1) })
+}
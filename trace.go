@@ -0,0 +1,131 @@
+package fp
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+//////////
+/// MustError call site capture
+
+type CaptureMode int32
+
+const (
+	CaptureNone   CaptureMode = iota // default; no overhead
+	CaptureCaller                    // file:line of the asserting call
+	CaptureStack                     // full stack trace
+)
+
+var captureMode atomic.Int32
+
+// Sets what MustError captures about its call site globally. Capturing
+// requires walking the stack, so keep it off for hot paths.
+func SetCapture(m CaptureMode) {
+	captureMode.Store(int32(m))
+}
+
+func Capture() CaptureMode {
+	return CaptureMode(captureMode.Load())
+}
+
+const pkgPrefix = "github.com/loorke/fp."
+
+// Attaches call site to e according to the capture mode; frames of this
+// package are skipped, so the stack starts where the assertion was made.
+func (e MustError) capture() MustError {
+	mode := Capture()
+	if mode == CaptureNone {
+		return e
+	}
+
+	pcs := make([]uintptr, 64)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	// Inlined calls make frames and PCs diverge, so trim by frame
+	var kept []runtime.Frame
+	frames := runtime.CallersFrames(pcs)
+	for more := len(pcs) > 0; more; {
+		var f runtime.Frame
+		f, more = frames.Next()
+		if kept == nil && strings.HasPrefix(f.Function, pkgPrefix) &&
+			!strings.HasSuffix(f.File, "_test.go") {
+			continue
+		}
+		kept = append(kept, f)
+		if mode == CaptureCaller {
+			break
+		}
+	}
+	if kept == nil {
+		return e
+	}
+
+	e.site = &callSite{kept}
+	return e
+}
+
+// Captured frames; held by pointer, so that MustError stays comparable
+type callSite struct {
+	frames []runtime.Frame
+}
+
+// Returns program counters of the captured frames, one per frame; see
+// SetCapture()
+func (e MustError) Stack() []uintptr {
+	return Map(func(f runtime.Frame) uintptr {
+		return f.PC
+	}, e.Frames()...)
+}
+
+// Returns captured frames, the first one is the caller; see SetCapture()
+func (e MustError) Frames() []runtime.Frame {
+	if e.site == nil {
+		return nil
+	}
+	return e.site.frames
+}
+
+// Returns file:line of the asserting call if it was captured
+func (e MustError) Caller() string {
+	if len(e.Frames()) == 0 {
+		return ""
+	}
+	f := e.Frames()[0]
+	return fmt.Sprintf("%s:%d", f.File, f.Line)
+}
+
+// %+v appends captured call site or stack trace to the message
+func (e MustError) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, e.Error())
+		for _, f := range e.Frames() {
+			fmt.Fprintf(s, "\n%s\n\t%s:%d", f.Function, f.File, f.Line)
+		}
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+func (e MustError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("msg", e.Msg),
+		slog.Any("val", e.Val),
+	}
+	if e.Pos != nil {
+		attrs = append(attrs, slog.Int("pos", *e.Pos))
+	}
+	if e.RequestID != "" {
+		attrs = append(attrs, slog.String("request_id", e.RequestID))
+	}
+	if c := e.Caller(); c != "" {
+		attrs = append(attrs, slog.String("caller", c))
+	}
+	return slog.GroupValue(attrs...)
+}
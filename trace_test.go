@@ -0,0 +1,82 @@
+package fp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func recoverMust(f func()) (me MustError) {
+	defer func() {
+		me = recover().(MustError)
+	}()
+	f()
+	return me
+}
+
+func TestCapture(t *testing.T) {
+	defer SetCapture(Capture())
+
+	{
+		SetCapture(CaptureNone)
+		me := recoverMust(func() { Must(Gt(0), "positive", 0) })
+		require.Empty(t, me.Stack())
+		require.Empty(t, me.Caller())
+		require.Equal(t, me.Error(), fmt.Sprintf("%+v", me))
+	}
+
+	{
+		SetCapture(CaptureCaller)
+		me := recoverMust(func() { NoError(0, errors.New("boom")) })
+		require.Len(t, me.Stack(), 1)
+		require.Contains(t, me.Caller(), "trace_test.go:")
+		require.Equal(t, "github.com/loorke/fp.TestCapture.func2",
+			me.Frames()[0].Function)
+	}
+
+	{
+		SetCapture(CaptureStack)
+		me := recoverMust(func() { MustGetIn[int](map[string]any{}, "a") })
+		require.Greater(t, len(me.Stack()), 1)
+		require.Contains(t, me.Caller(), "trace_test.go:")
+
+		verbose := fmt.Sprintf("%+v", me)
+		lines := strings.Split(verbose, "\n")
+		require.Equal(t, me.Error(), lines[0])
+		require.Contains(t, verbose, "testing.tRunner")
+		require.Equal(t, me.Error(), fmt.Sprintf("%v", me))
+
+		// Captured stack doesn't make MustError incomparable
+		var err error = me
+		require.True(t, err == me)
+		require.ErrorIs(t, fmt.Errorf("wrapped: %w", err), me)
+	}
+
+	{
+		// Assertions may get inlined into the caller, which shares a PC
+		// with them then
+		SetCapture(CaptureStack)
+		me := recoverMust(func() { Must(Gt(0), "", -1) })
+		require.Equal(t, "github.com/loorke/fp.TestCapture.func4",
+			me.Frames()[0].Function)
+		require.Len(t, me.Stack(), len(me.Frames()))
+	}
+}
+
+func TestMustErrorLogValue(t *testing.T) {
+	defer SetCapture(Capture())
+	SetCapture(CaptureCaller)
+
+	me := recoverMust(func() { Must(Gt(0), "positive", 1, 0) })
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Error("failed", "err", me)
+	out := buf.String()
+	require.Contains(t, out, "err.msg=positive err.val=0 err.pos=1 err.caller=")
+	require.Contains(t, out, "trace_test.go:")
+}
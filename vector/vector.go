@@ -0,0 +1,381 @@
+/*
+Package vector provides persistent vectors: immutable indexed sequences
+backed by 32-way tries, in the manner of Clojure. Get, Set, Append and Pop
+take O(log32 n) time and return new versions sharing all but the changed
+path with the original, so old versions stay valid and cheap to keep:
+
+	v1 := vector.New(1, 2, 3)
+	v2 := v1.Set(0, 10) // v1 is still [1, 2, 3]
+
+Bulk loads should go through Builder, which mutates nodes it owns in place.
+*/
+package vector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/loorke/fp"
+)
+
+const (
+	bits  = 5
+	width = 1 << bits
+	mask  = width - 1
+)
+
+// Identity of a Builder; non-zero size, so that pointers are distinct
+type owner struct{ _ int }
+
+// Branch nodes have kids, leaves have exactly width vals
+type node[tA any] struct {
+	kids []*node[tA]
+	vals []tA
+	// Builder allowed to mutate the node in place, if any
+	edit *owner
+}
+
+// Persistent vector; the zero value is an empty vector
+type Vector[tA any] struct {
+	cnt   int
+	shift uint
+	root  *node[tA]
+	// Last up to width elements, kept out of the trie for fast appends
+	tail []tA
+}
+
+func New[tA any](a ...tA) Vector[tA] {
+	return NewBuilder[tA]().Append(a...).Vector()
+}
+
+func (v Vector[tA]) Len() int {
+	return v.cnt
+}
+
+// Checked by hand, so that the hot path doesn't allocate a predicate
+func (v Vector[tA]) checkIndex(i int) {
+	if i < 0 || i >= v.cnt {
+		fp.Must(fp.Const[bool, int](false), "index out of range", i)
+	}
+}
+
+// Number of elements stored in the trie
+func (v Vector[tA]) tailOff() int {
+	if v.cnt < width {
+		return 0
+	}
+	return ((v.cnt - 1) >> bits) << bits
+}
+
+// Returns leaf or tail holding i-th element
+func (v Vector[tA]) chunkFor(i int) []tA {
+	if i >= v.tailOff() {
+		return v.tail
+	}
+	n := v.root
+	for level := v.shift; level > 0; level -= bits {
+		n = n.kids[(i>>level)&mask]
+	}
+	return n.vals
+}
+
+// Returns i-th element; panics with MustError if i is out of range
+func (v Vector[tA]) Get(i int) tA {
+	v.checkIndex(i)
+	return v.chunkFor(i)[i&mask]
+}
+
+// Returns vector with i-th element replaced by x; i may be Len() to append
+func (v Vector[tA]) Set(i int, x tA) Vector[tA] {
+	if i == v.cnt {
+		return v.Append(x)
+	}
+	v.checkIndex(i)
+
+	if i >= v.tailOff() {
+		v.tail = fp.Concat(v.tail)
+		v.tail[i&mask] = x
+		return v
+	}
+	v.root = set(v.shift, v.root, i, x)
+	return v
+}
+
+func set[tA any](level uint, n *node[tA], i int, x tA) *node[tA] {
+	if level == 0 {
+		vals := fp.Concat(n.vals)
+		vals[i&mask] = x
+		return &node[tA]{vals: vals}
+	}
+	kids := fp.Concat(n.kids)
+	sub := (i >> level) & mask
+	kids[sub] = set(level-bits, kids[sub], i, x)
+	return &node[tA]{kids: kids}
+}
+
+// Returns vector with x appended
+func (v Vector[tA]) Append(x tA) Vector[tA] {
+	if len(v.tail) < width {
+		// Clipped, so that versions sharing the tail never overwrite it
+		v.tail = append(v.tail[:len(v.tail):len(v.tail)], x)
+		v.cnt++
+		return v
+	}
+	return v.pushTail(nil, x)
+}
+
+// Moves full tail into the trie and starts a new one with x; nodes owned by
+// edit are updated in place
+func (v Vector[tA]) pushTail(edit *owner, x tA) Vector[tA] {
+	leaf := &node[tA]{vals: v.tail, edit: edit}
+	switch {
+	case v.root == nil:
+		v.root = &node[tA]{kids: []*node[tA]{leaf}, edit: edit}
+		v.shift = bits
+	case v.cnt>>bits > 1<<v.shift:
+		// Root is full, grow the trie by a level
+		v.root = &node[tA]{
+			kids: []*node[tA]{v.root, newPath(edit, v.shift, leaf)},
+			edit: edit,
+		}
+		v.shift += bits
+	default:
+		v.root = v.pushLeaf(edit, v.shift, v.root, leaf)
+	}
+
+	v.tail = []tA{x}
+	if edit != nil {
+		v.tail = append(make([]tA, 0, width), x)
+	}
+	v.cnt++
+	return v
+}
+
+func (v Vector[tA]) pushLeaf(
+	edit *owner,
+	level uint,
+	parent, leaf *node[tA],
+) *node[tA] {
+	n := editable(edit, parent)
+	sub := ((v.cnt - 1) >> level) & mask
+
+	ins := leaf
+	if level > bits {
+		if sub < len(n.kids) {
+			ins = v.pushLeaf(edit, level-bits, n.kids[sub], leaf)
+		} else {
+			ins = newPath(edit, level-bits, leaf)
+		}
+	}
+
+	if sub < len(n.kids) {
+		n.kids[sub] = ins
+	} else {
+		n.kids = append(n.kids, ins)
+	}
+	return n
+}
+
+// Returns n itself if it's owned by edit, its copy otherwise
+func editable[tA any](edit *owner, n *node[tA]) *node[tA] {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+	kids := fp.Concat(n.kids)
+	if edit != nil {
+		kids = append(make([]*node[tA], 0, width), n.kids...)
+	}
+	return &node[tA]{kids: kids, edit: edit}
+}
+
+// Wraps leaf into branches down from level
+func newPath[tA any](edit *owner, level uint, leaf *node[tA]) *node[tA] {
+	if level == 0 {
+		return leaf
+	}
+	return &node[tA]{
+		kids: []*node[tA]{newPath(edit, level-bits, leaf)},
+		edit: edit,
+	}
+}
+
+// Returns vector without the last element; panics with MustError if v is
+// empty
+func (v Vector[tA]) Pop() Vector[tA] {
+	fp.Must(fp.Gt(0), "pop from empty vector", v.cnt)
+
+	if v.cnt == 1 {
+		return Vector[tA]{}
+	}
+	if v.cnt-v.tailOff() > 1 {
+		v.tail = v.tail[:len(v.tail)-1]
+		v.cnt--
+		return v
+	}
+
+	v.tail = v.chunkFor(v.cnt - 2)
+	v.root = v.popLeaf(v.shift, v.root)
+	switch {
+	case v.root == nil:
+		v.shift = 0
+	case v.shift > bits && len(v.root.kids) == 1:
+		v.root = v.root.kids[0]
+		v.shift -= bits
+	}
+	v.cnt--
+	return v
+}
+
+// Returns n without its last leaf, nil if nothing is left
+func (v Vector[tA]) popLeaf(level uint, n *node[tA]) *node[tA] {
+	sub := ((v.cnt - 2) >> level) & mask
+	var kid *node[tA]
+	if level > bits {
+		kid = v.popLeaf(level-bits, n.kids[sub])
+	}
+	if kid == nil && sub == 0 {
+		return nil
+	}
+
+	kids := fp.Concat(n.kids[:sub+1])
+	if kid == nil {
+		kids = kids[:sub]
+	} else {
+		kids[sub] = kid
+	}
+	return &node[tA]{kids: kids}
+}
+
+// Returns elements in [lo, hi) as a new vector in O(hi - lo); panics with
+// MustError if the bounds are invalid
+func (v Vector[tA]) Slice(lo, hi int) Vector[tA] {
+	fp.Must(func(b [2]int) bool {
+		return 0 <= b[0] && b[0] <= b[1] && b[1] <= v.cnt
+	}, "slice bounds out of range", [2]int{lo, hi})
+
+	if lo == 0 && hi == v.cnt {
+		return v
+	}
+	b := NewBuilder[tA]()
+	v.chunks(lo, hi, func(c []tA) bool {
+		b.Append(c...)
+		return true
+	})
+	return b.Vector()
+}
+
+// Calls f for consecutive chunks covering [lo, hi) until it returns false
+func (v Vector[tA]) chunks(lo, hi int, f func([]tA) bool) {
+	for i := lo; i < hi; {
+		c := v.chunkFor(i)
+		end := min(i-i&mask+len(c), hi)
+		if !f(c[i&mask : end-(i-i&mask)]) {
+			return
+		}
+		i = end
+	}
+}
+
+func (v Vector[tA]) ToSlice() []tA {
+	res := make([]tA, 0, v.cnt)
+	v.chunks(0, v.cnt, func(c []tA) bool {
+		res = append(res, c...)
+		return true
+	})
+	return res
+}
+
+// Formats v as "[1, 2, 3]"
+func (v Vector[tA]) String() string {
+	strs := fp.Map(func(x tA) string {
+		return fmt.Sprint(x)
+	}, v.ToSlice()...)
+	return "[" + strings.Join(strs, ", ") + "]"
+}
+
+// Transient vector for bulk loads. It mutates nodes it has created in place,
+// so it must not be used concurrently; published vectors are never affected.
+type Builder[tA any] struct {
+	v    Vector[tA]
+	edit *owner
+	// Tail was allocated by the builder, so it may be appended in place
+	ownTail bool
+}
+
+func NewBuilder[tA any]() *Builder[tA] {
+	return &Builder[tA]{edit: new(owner)}
+}
+
+// Returns builder starting with elements of v
+func (v Vector[tA]) Builder() *Builder[tA] {
+	return &Builder[tA]{v: v, edit: new(owner)}
+}
+
+func (b *Builder[tA]) Len() int {
+	return b.v.cnt
+}
+
+func (b *Builder[tA]) Append(a ...tA) *Builder[tA] {
+	for _, x := range a {
+		switch {
+		case len(b.v.tail) == width:
+			b.v = b.v.pushTail(b.edit, x)
+			b.ownTail = true
+		case b.ownTail:
+			b.v.tail = append(b.v.tail, x)
+			b.v.cnt++
+		default:
+			b.v.tail = append(append(make([]tA, 0, width), b.v.tail...), x)
+			b.v.cnt++
+			b.ownTail = true
+		}
+	}
+	return b
+}
+
+// Returns vector built so far; the builder remains usable, but won't touch
+// nodes shared with the returned vector anymore
+func (b *Builder[tA]) Vector() Vector[tA] {
+	b.edit = new(owner)
+	b.ownTail = false
+	v := b.v
+	v.tail = v.tail[:len(v.tail):len(v.tail)]
+	return v
+}
+
+func Map[
+	tA, tB any,
+	tF ~func(tA) tB,
+](f tF, v Vector[tA]) Vector[tB] {
+	b := NewBuilder[tB]()
+	v.chunks(0, v.cnt, func(c []tA) bool {
+		b.Append(fp.Map(f, c...)...)
+		return true
+	})
+	return b.Vector()
+}
+
+func Filter[
+	tA any,
+	tF ~func(tA) bool,
+](p tF, v Vector[tA]) Vector[tA] {
+	b := NewBuilder[tA]()
+	v.chunks(0, v.cnt, func(c []tA) bool {
+		b.Append(fp.Filter(p, c...)...)
+		return true
+	})
+	return b.Vector()
+}
+
+// See fp.Reduce()
+func Reduce[
+	tA, tB any,
+	tF ~func(tB, tA) tB,
+](f tF, z tB, v Vector[tA]) tB {
+	acc := z
+	v.chunks(0, v.cnt, func(c []tA) bool {
+		acc = fp.Reduce(f, acc, c...)
+		return true
+	})
+	return acc
+}
@@ -0,0 +1,150 @@
+package vector
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/loorke/fp"
+	"github.com/stretchr/testify/require"
+)
+
+// Spans three levels of the trie
+const big = width*width*width + width + 3
+
+func TestVector(t *testing.T) {
+	v := New(1, 2, 3)
+	require.Equal(t, 3, v.Len())
+	require.Equal(t, 2, v.Get(1))
+	require.Equal(t, "[1, 2, 3]", v.String())
+
+	{
+		w := v.Set(0, 10).Append(4)
+		require.Equal(t, []int{10, 2, 3, 4}, w.ToSlice())
+		require.Equal(t, []int{1, 2, 3}, v.ToSlice())
+		require.Equal(t, []int{1, 2, 3, 5}, v.Set(3, 5).ToSlice())
+	}
+
+	{
+		var e Vector[int]
+		require.Equal(t, "[]", e.String())
+		require.Empty(t, e.ToSlice())
+		require.Panics(t, func() { e.Get(0) })
+		require.Panics(t, func() { e.Pop() })
+		require.Panics(t, func() { v.Get(-1) })
+		require.Panics(t, func() { v.Set(4, 0) })
+		require.Equal(t, []int{1}, e.Append(1).ToSlice())
+	}
+
+	// Indexing doesn't allocate
+	require.Zero(t, testing.AllocsPerRun(100, func() { v.Get(1) }))
+}
+
+func TestAppendPop(t *testing.T) {
+	var v Vector[int]
+	versions := []Vector[int]{v}
+	for i := 0; i < big; i++ {
+		v = v.Append(i)
+		versions = append(versions, v)
+	}
+	require.Equal(t, big, v.Len())
+	for i := 0; i < big; i++ {
+		require.Equal(t, i, v.Get(i))
+	}
+
+	// Old versions are intact
+	for _, n := range []int{0, 1, 31, 32, 33, 1024, 1025, 1056, big} {
+		require.Equal(t, seq(0, n), versions[n].ToSlice())
+	}
+
+	for n := big; n > 0; n-- {
+		v = v.Pop()
+		require.Equal(t, n-1, v.Len())
+		if n-1 > 0 {
+			require.Equal(t, n-2, v.Get(n-2))
+		}
+		if n%1000 == 0 {
+			require.Equal(t, seq(0, n-1), v.ToSlice())
+		}
+	}
+	require.Equal(t, Vector[int]{}, v)
+}
+
+// Random operations checked against a slice model
+func TestModel(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var v Vector[int]
+	var model []int
+	for op := 0; op < 20000; op++ {
+		switch r := rnd.Intn(10); {
+		case r < 6:
+			v = v.Append(op)
+			model = append(model, op)
+		case r < 8 && len(model) > 0:
+			i := rnd.Intn(len(model))
+			v = v.Set(i, -op)
+			model[i] = -op
+		case len(model) > 0:
+			v = v.Pop()
+			model = model[:len(model)-1]
+		}
+	}
+	require.Equal(t, model, v.ToSlice())
+	for i, x := range model {
+		require.Equal(t, x, v.Get(i))
+	}
+}
+
+func TestSlice(t *testing.T) {
+	v := New(seq(0, 2000)...)
+	require.Equal(t, seq(40, 1500), v.Slice(40, 1500).ToSlice())
+	require.Equal(t, v, v.Slice(0, 2000))
+	require.Equal(t, 0, v.Slice(5, 5).Len())
+	require.Panics(t, func() { v.Slice(5, 4) })
+	require.Panics(t, func() { v.Slice(0, 2001) })
+}
+
+func TestBuilder(t *testing.T) {
+	b := NewBuilder[int]().Append(seq(0, 100)...)
+	v1 := b.Vector()
+	b.Append(100, 101)
+	v2 := b.Vector()
+	b.Append(seq(102, 2000)...)
+	v3 := b.Vector()
+
+	require.Equal(t, seq(0, 100), v1.ToSlice())
+	require.Equal(t, seq(0, 102), v2.ToSlice())
+	require.Equal(t, seq(0, 2000), v3.ToSlice())
+
+	{
+		// Builders and appends forked from one version don't interfere
+		b1, b2 := v1.Builder(), v1.Builder()
+		b1.Append(-1)
+		b2.Append(-2)
+		w := v1.Append(-3)
+		require.Equal(t, -1, b1.Vector().Get(100))
+		require.Equal(t, -2, b2.Vector().Get(100))
+		require.Equal(t, -3, w.Get(100))
+		require.Equal(t, 100, v1.Len())
+	}
+}
+
+func TestMapFilterReduce(t *testing.T) {
+	v := New(seq(0, 1000)...)
+	require.Equal(t,
+		fp.Map(strconv.Itoa, seq(0, 1000)...),
+		Map(strconv.Itoa, v).ToSlice())
+	require.Equal(t,
+		fp.Filter(fp.IsEven[int], seq(0, 1000)...),
+		Filter(fp.IsEven[int], v).ToSlice())
+	require.Equal(t, 499500, Reduce(fp.Apply2(fp.Sum[int]), 0, v))
+}
+
+// Returns [lo, hi)
+func seq(lo, hi int) []int {
+	res := make([]int, 0, hi-lo)
+	for i := lo; i < hi; i++ {
+		res = append(res, i)
+	}
+	return res
+}